@@ -1,6 +1,7 @@
 package buildpack_config
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -34,7 +35,7 @@ func GetDependenciesById(id string, config cargo.Config) ([]versionology.Depende
 	})
 
 	return collections.TransformFuncWithError(dependencies, func(dependency cargo.ConfigMetadataDependency) (versionology.Dependency, error) {
-		return versionology.NewDependency(dependency, "")
+		return versionology.NewDependencyFromConfig(dependency)
 	})
 }
 
@@ -46,15 +47,135 @@ func GetDependenciesByIdAndStack(id, stack string, config cargo.Config) ([]versi
 	})
 
 	return collections.TransformFuncWithError(dependencies, func(dependency cargo.ConfigMetadataDependency) (versionology.Dependency, error) {
-		return versionology.NewDependency(dependency, "")
+		return versionology.NewDependencyFromConfig(dependency)
 	})
 }
 
-// GetConstraintsById will return an array of constraints with the given id
+// DependencyExclusion represents a `[[metadata.dependency-exclusions]]` entry of a buildpack.toml.
+// Each entry of Exclude is either an exact version (e.g. "3.11.0") or a semver range (e.g. ">=2.0.0-0 <2.0.3").
+// When Constraint is empty the exclusions apply to every constraint with the same id,
+// otherwise they only apply to the constraint with that exact string.
+//
+//	[[metadata.dependency-exclusions]]
+//	  id = "python"
+//	  constraint = "3.11.*"
+//	  exclude = ["3.11.0", ">=3.11.1-0 <3.11.3"]
+type DependencyExclusion struct {
+	ID         string   `toml:"id"         json:"id"`
+	Constraint string   `toml:"constraint" json:"constraint,omitempty"`
+	Exclude    []string `toml:"exclude"    json:"exclude"`
+}
+
+// GetExclusionsById will return an array of the `[[metadata.dependency-exclusions]]` entries with the given id
+func GetExclusionsById(id string, config cargo.Config) ([]DependencyExclusion, error) {
+	var exclusions []DependencyExclusion
+	if err := parseUnstructuredMetadata(config, "dependency-exclusions", &exclusions); err != nil {
+		return nil, err
+	}
+
+	return collections.FilterFunc(exclusions, func(e DependencyExclusion) bool {
+		return e.ID == id
+	}), nil
+}
+
+// DependencyPatchWindow represents a `[[metadata.dependency-patch-windows]]` entry of a buildpack.toml.
+// Window is either "constraint" (the default) or "minor", see versionology.PatchWindow.
+// The "minor" window only covers the lines from the oldest existing minor line of the constraint upwards,
+// e.g. 3.11.* and newer when 3.11 and 3.12 exist, rather than every line from 3.0 on.
+// Backfill will also return versions inside the window that are missing from the buildpack.toml,
+// not only those newer than every existing dependency.
+// When Constraint is empty the window applies to every constraint with the same id,
+// otherwise it only applies to the constraint with that exact string.
+//
+//	[[metadata.dependency-patch-windows]]
+//	  id = "python"
+//	  constraint = "3.*"
+//	  window = "minor"
+//	  backfill = true
+type DependencyPatchWindow struct {
+	ID         string `toml:"id"         json:"id"`
+	Constraint string `toml:"constraint" json:"constraint,omitempty"`
+	Window     string `toml:"window"     json:"window,omitempty"`
+	Backfill   bool   `toml:"backfill"   json:"backfill,omitempty"`
+}
+
+// GetPatchWindowsById will return an array of the `[[metadata.dependency-patch-windows]]` entries with the given id
+func GetPatchWindowsById(id string, config cargo.Config) ([]DependencyPatchWindow, error) {
+	var windows []DependencyPatchWindow
+	if err := parseUnstructuredMetadata(config, "dependency-patch-windows", &windows); err != nil {
+		return nil, err
+	}
+
+	return collections.FilterFunc(windows, func(w DependencyPatchWindow) bool {
+		return w.ID == id
+	}), nil
+}
+
+// parseUnstructuredMetadata will unmarshal the `[metadata]` key that cargo does not know about into v
+func parseUnstructuredMetadata(config cargo.Config, key string, v any) error {
+	raw, ok := config.Metadata.Unstructured[key]
+	if !ok {
+		return nil
+	}
+
+	// Unstructured holds a json.RawMessage when parsed from buildpack.toml,
+	// but may hold plain maps when the config was built in code.
+	content, err := json.Marshal(raw)
+	if err != nil { //untested
+		return err
+	}
+
+	if err = json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("unable to parse metadata.%s: %w", key, err)
+	}
+
+	return nil
+}
+
+// GetConstraintsById will return an array of constraints with the given id,
+// including any exclusions from `[[metadata.dependency-exclusions]]`
+// and the patch window from `[[metadata.dependency-patch-windows]]` that apply to each constraint
 func GetConstraintsById(id string, config cargo.Config) ([]versionology.Constraint, error) {
 	constraints := collections.FilterFunc(config.Metadata.DependencyConstraints, func(c cargo.ConfigMetadataDependencyConstraint) bool {
 		return c.ID == id
 	})
 
-	return collections.TransformFuncWithError(constraints, versionology.NewConstraint)
+	exclusions, err := GetExclusionsById(id, config)
+	if err != nil {
+		return nil, err
+	}
+
+	windows, err := GetPatchWindowsById(id, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return collections.TransformFuncWithError(constraints, func(c cargo.ConfigMetadataDependencyConstraint) (versionology.Constraint, error) {
+		var exclude []string
+		for _, exclusion := range exclusions {
+			if exclusion.Constraint == "" || exclusion.Constraint == c.Constraint {
+				exclude = append(exclude, exclusion.Exclude...)
+			}
+		}
+
+		constraint, err := versionology.NewConstraintWithExclusions(c, exclude...)
+		if err != nil {
+			return versionology.Constraint{}, err
+		}
+
+		// A window scoped to this exact constraint takes precedence over one for the whole id
+		for _, scope := range []string{"", c.Constraint} {
+			for _, window := range windows {
+				if window.Constraint != scope {
+					continue
+				}
+				if constraint.Window, err = versionology.NewPatchWindow(window.Window); err != nil {
+					return versionology.Constraint{}, err
+				}
+				constraint.Backfill = window.Backfill
+			}
+		}
+
+		return constraint, nil
+	})
 }
@@ -61,6 +61,29 @@ func testBuildpackToml(t *testing.T, context spec.G, it spec.S) {
 			Expect(versionology.Versions(dependencies)).To(ConsistOf("2.2.2", "3.3.3", "4.4.4"))
 		})
 
+		it("will fill the target from the os, arch, distros and stacks", func() {
+			config.Metadata.Dependencies = append(config.Metadata.Dependencies, cargo.ConfigMetadataDependency{
+				ID:      "id4",
+				Version: "6.6.6",
+				OS:      "linux",
+				Arch:    "arm64",
+				Distros: []cargo.ConfigDistro{{Name: "ubuntu", Version: "24.04"}},
+				Stacks:  []string{"stack1"},
+			})
+
+			dependencies, err := buildpack_config.GetDependenciesById("id4", config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dependencies).To(HaveLen(1))
+
+			target := dependencies[0].Target
+			Expect(target.OS).To(Equal("linux"))
+			Expect(target.Arch).To(Equal("arm64"))
+			Expect(target.DistroName).To(Equal("ubuntu"))
+			Expect(target.DistroVersion).To(Equal("24.04"))
+			Expect(target.Stacks).To(Equal([]string{"stack1"}))
+			Expect(target.String()).To(BeEmpty())
+		})
+
 		context("failure cases", func() {
 			it.Before(func() {
 				config.Metadata.Dependencies = append(config.Metadata.Dependencies, cargo.ConfigMetadataDependency{
@@ -132,6 +155,36 @@ func testBuildpackToml(t *testing.T, context spec.G, it spec.S) {
 			Expect(versionology.ConstraintsToString(constraints)).To(ConsistOf("2.*", ">=3.4.5"))
 		})
 
+		it("will attach exclusions to the matching constraints", func() {
+			config, err := buildpack_config.ParseBuildpackToml(filepath.Join("testdata", "exclusions", "buildpack.toml"))
+			Expect(err).NotTo(HaveOccurred())
+
+			constraints, err := buildpack_config.GetConstraintsById("dep", config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(constraints).To(HaveLen(2))
+
+			Expect(constraints[0].Exclusions.Len()).To(Equal(1))
+			Expect(constraints[0].Exclusions.All()[0].String()).To(Equal("2.0.1"))
+
+			Expect(constraints[1].Exclusions.Len()).To(Equal(2))
+			Expect(constraints[1].Exclusions.All()[0].String()).To(Equal("2.0.1"))
+			Expect(constraints[1].Exclusions.All()[1].String()).To(Equal(">=3.1.0-0 <3.1.3"))
+		})
+
+		it("will apply patch windows to the matching constraints", func() {
+			config, err := buildpack_config.ParseBuildpackToml(filepath.Join("testdata", "patch-windows", "buildpack.toml"))
+			Expect(err).NotTo(HaveOccurred())
+
+			constraints, err := buildpack_config.GetConstraintsById("dep", config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(constraints).To(HaveLen(2))
+
+			Expect(constraints[0].Window).To(Equal(versionology.PatchWindowConstraint))
+			Expect(constraints[0].Backfill).To(BeFalse())
+			Expect(constraints[1].Window).To(Equal(versionology.PatchWindowMinor))
+			Expect(constraints[1].Backfill).To(BeTrue())
+		})
+
 		context("failure cases", func() {
 			it("will return error if constraint is not valid semver", func() {
 				_, err := buildpack_config.GetConstraintsById("id1", cargo.Config{
@@ -143,6 +196,71 @@ func testBuildpackToml(t *testing.T, context spec.G, it spec.S) {
 				})
 				Expect(err).To(HaveOccurred())
 			})
+
+			it("will return error if a patch window is unknown", func() {
+				_, err := buildpack_config.GetConstraintsById("id1", cargo.Config{
+					Metadata: cargo.ConfigMetadata{
+						DependencyConstraints: []cargo.ConfigMetadataDependencyConstraint{
+							{ID: "id1", Constraint: "1.*"},
+						},
+						Unstructured: map[string]interface{}{
+							"dependency-patch-windows": []map[string]interface{}{
+								{"id": "id1", "window": "major"},
+							},
+						},
+					},
+				})
+				Expect(err).To(MatchError("unknown patch window 'major', must be one of 'constraint' or 'minor'"))
+			})
+
+			it("will return error if an exclusion is not valid semver", func() {
+				_, err := buildpack_config.GetConstraintsById("id1", cargo.Config{
+					Metadata: cargo.ConfigMetadata{
+						DependencyConstraints: []cargo.ConfigMetadataDependencyConstraint{
+							{ID: "id1", Constraint: "1.*"},
+						},
+						Unstructured: map[string]interface{}{
+							"dependency-exclusions": []map[string]interface{}{
+								{"id": "id1", "exclude": []string{"foo"}},
+							},
+						},
+					},
+				})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	context("GetExclusionsById", func() {
+		it("will filter by id", func() {
+			config, err := buildpack_config.ParseBuildpackToml(filepath.Join("testdata", "exclusions", "buildpack.toml"))
+			Expect(err).NotTo(HaveOccurred())
+
+			exclusions, err := buildpack_config.GetExclusionsById("dep", config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exclusions).To(Equal([]buildpack_config.DependencyExclusion{
+				{ID: "dep", Exclude: []string{"2.0.1"}},
+				{ID: "dep", Constraint: "3.*.*", Exclude: []string{">=3.1.0-0 <3.1.3"}},
+			}))
+		})
+
+		it("will return an empty array when there are no exclusions", func() {
+			exclusions, err := buildpack_config.GetExclusionsById("dep", cargo.Config{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exclusions).To(BeEmpty())
+		})
+
+		context("failure cases", func() {
+			it("will return error if the exclusions are malformed", func() {
+				_, err := buildpack_config.GetExclusionsById("dep", cargo.Config{
+					Metadata: cargo.ConfigMetadata{
+						Unstructured: map[string]interface{}{
+							"dependency-exclusions": "not a table",
+						},
+					},
+				})
+				Expect(err).To(MatchError(ContainSubstring("unable to parse metadata.dependency-exclusions")))
+			})
 		})
 	})
 }
@@ -10,5 +10,6 @@ import (
 func TestUnitFuncs(t *testing.T) {
 	suite := spec.New("libdependency", spec.Report(report.Terminal{}))
 	suite("buildpackToml", testBuildpackToml)
+	suite("lint", testLint)
 	suite.Run(t)
 }
@@ -0,0 +1,134 @@
+package buildpack_config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+)
+
+// Lint will report problems with the dependencies and constraints of a buildpack.toml.
+// For each dependency id it will report:
+// - the problems found by versionology.LintConstraints
+// - duplicate dependency entries with the same id, version, stack, os, arch and distros
+// - dependencies without a checksum
+// - dependencies with a source but without a source checksum
+//
+// upstreamVersions is optional, and maps a dependency id to all of its known upstream versions.
+func Lint(config cargo.Config, upstreamVersions map[string]versionology.VersionFetcherArray) ([]versionology.LintFinding, error) {
+	var ids []string
+	seenIds := make(map[string]bool)
+	for _, dependency := range config.Metadata.Dependencies {
+		if !seenIds[dependency.ID] {
+			seenIds[dependency.ID] = true
+			ids = append(ids, dependency.ID)
+		}
+	}
+	for _, constraint := range config.Metadata.DependencyConstraints {
+		if !seenIds[constraint.ID] {
+			seenIds[constraint.ID] = true
+			ids = append(ids, constraint.ID)
+		}
+	}
+	sort.Strings(ids)
+
+	var findings []versionology.LintFinding
+
+	for _, id := range ids {
+		dependencies, err := GetDependenciesById(id, config)
+		if err != nil {
+			return nil, fmt.Errorf("unable to lint %s: %w", id, err)
+		}
+
+		constraints, err := GetConstraintsById(id, config)
+		if err != nil {
+			return nil, fmt.Errorf("unable to lint %s: %w", id, err)
+		}
+
+		existingVersions := versionology.NewVersionFetcherArray()
+		for _, dependency := range dependencies {
+			existingVersions = append(existingVersions, dependency)
+		}
+
+		findings = append(findings, versionology.LintConstraints(id, constraints, existingVersions, upstreamVersions[id])...)
+		findings = append(findings, lintDependencies(id, dependencies)...)
+	}
+
+	return findings, nil
+}
+
+func lintDependencies(id string, dependencies []versionology.Dependency) []versionology.LintFinding {
+	var findings []versionology.LintFinding
+
+	seen := make(map[string]bool)
+	for _, dependency := range dependencies {
+		version := dependency.SemverVersion.String()
+		distros := describeDistros(dependency.Distros)
+
+		target := dependency.Target
+		stacks := target.Stacks
+		if len(stacks) < 1 {
+			stacks = []string{""}
+		}
+
+		for _, stack := range stacks {
+			key := strings.Join([]string{version, stack, target.OS, target.Arch, distros}, "|")
+			if seen[key] {
+				findings = append(findings, versionology.LintFinding{
+					Severity: versionology.SeverityError,
+					ID:       id,
+					Message:  fmt.Sprintf("dependency %s is duplicated%s", version, describeTarget(stack, target.OS, target.Arch, distros)),
+				})
+			}
+			seen[key] = true
+		}
+
+		if dependency.Checksum == "" && dependency.SHA256 == "" {
+			findings = append(findings, versionology.LintFinding{
+				Severity: versionology.SeverityError,
+				ID:       id,
+				Message:  fmt.Sprintf("dependency %s is missing a checksum", version),
+			})
+		}
+
+		if dependency.Source != "" && dependency.SourceChecksum == "" && dependency.SourceSHA256 == "" {
+			findings = append(findings, versionology.LintFinding{
+				Severity: versionology.SeverityWarning,
+				ID:       id,
+				Message:  fmt.Sprintf("dependency %s is missing a source checksum", version),
+			})
+		}
+	}
+
+	return findings
+}
+
+func describeTarget(stack, os, arch, distros string) string {
+	var parts []string
+	if stack != "" {
+		parts = append(parts, fmt.Sprintf("stack %s", stack))
+	}
+	if os != "" || arch != "" {
+		parts = append(parts, fmt.Sprintf("target %s/%s", os, arch))
+	}
+	if distros != "" {
+		parts = append(parts, fmt.Sprintf("distros %s", distros))
+	}
+	if len(parts) < 1 {
+		return ""
+	}
+	return fmt.Sprintf(" for %s", strings.Join(parts, " and "))
+}
+
+// describeDistros will return the distros as a sorted, comma separated list of name@version,
+// so that the order in which they are listed does not matter
+func describeDistros(distros []cargo.ConfigDistro) string {
+	names := make([]string, 0, len(distros))
+	for _, distro := range distros {
+		names = append(names, fmt.Sprintf("%s@%s", distro.Name, distro.Version))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
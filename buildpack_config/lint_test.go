@@ -0,0 +1,120 @@
+package buildpack_config_test
+
+import (
+	"testing"
+
+	"github.com/paketo-buildpacks/libdependency/buildpack_config"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testLint(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	context("Lint", func() {
+		var config cargo.Config
+
+		it.Before(func() {
+			config = cargo.Config{
+				Metadata: cargo.ConfigMetadata{
+					Dependencies: []cargo.ConfigMetadataDependency{
+						{ID: "dep", Version: "1.0.0", Stacks: []string{"stack1", "stack2"}, Checksum: "sha256:abc"},
+						{ID: "dep", Version: "1.0.0", Stacks: []string{"stack2"}, SHA256: "abc"},
+						{ID: "dep", Version: "1.0.0", OS: "linux", Arch: "arm64", Checksum: "sha256:abc"},
+						{ID: "dep", Version: "1.1.0", Source: "https://example.com/dep-1.1.0.tgz"},
+						{ID: "other", Version: "2.0.0", Checksum: "sha256:def"},
+					},
+					DependencyConstraints: []cargo.ConfigMetadataDependencyConstraint{
+						{ID: "dep", Constraint: "1.*"},
+						{ID: "other", Constraint: "3.*"},
+					},
+				},
+			}
+		})
+
+		it("will report problems with the dependencies and constraints", func() {
+			findings, err := buildpack_config.Lint(config, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(findings).To(Equal([]versionology.LintFinding{
+				{
+					Severity: versionology.SeverityError,
+					ID:       "dep",
+					Message:  "dependency 1.0.0 is duplicated for stack stack2",
+				},
+				{
+					Severity: versionology.SeverityError,
+					ID:       "dep",
+					Message:  "dependency 1.1.0 is missing a checksum",
+				},
+				{
+					Severity: versionology.SeverityWarning,
+					ID:       "dep",
+					Message:  "dependency 1.1.0 is missing a source checksum",
+				},
+				{
+					Severity: versionology.SeverityWarning,
+					ID:       "other",
+					Message:  "constraint 3.* is not satisfied by any existing dependency",
+				},
+				{
+					Severity: versionology.SeverityWarning,
+					ID:       "other",
+					Message:  "dependency 2.0.0 does not satisfy any constraint",
+				},
+			}))
+		})
+
+		it("will use upstream versions when provided", func() {
+			upstreamVersions, err := versionology.NewSimpleVersionFetcherArray("1.0.0", "1.1.0", "2.0.0")
+			Expect(err).NotTo(HaveOccurred())
+
+			findings, err := buildpack_config.Lint(config, map[string]versionology.VersionFetcherArray{
+				"other": upstreamVersions,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(findings).To(ContainElement(versionology.LintFinding{
+				Severity: versionology.SeverityWarning,
+				ID:       "other",
+				Message:  "constraint 3.* does not match any upstream version",
+			}))
+		})
+
+		it("will not report entries that only differ by distro as duplicated", func() {
+			config.Metadata.Dependencies = append(config.Metadata.Dependencies,
+				cargo.ConfigMetadataDependency{ID: "distro", Version: "3.0.0", OS: "linux", Arch: "amd64", Checksum: "sha256:abc",
+					Distros: []cargo.ConfigDistro{{Name: "ubuntu", Version: "22.04"}}},
+				cargo.ConfigMetadataDependency{ID: "distro", Version: "3.0.0", OS: "linux", Arch: "amd64", Checksum: "sha256:def",
+					Distros: []cargo.ConfigDistro{{Name: "ubuntu", Version: "24.04"}}},
+				cargo.ConfigMetadataDependency{ID: "distro", Version: "3.0.0", OS: "linux", Arch: "amd64", Checksum: "sha256:ghi",
+					Distros: []cargo.ConfigDistro{{Name: "ubuntu", Version: "24.04"}}},
+			)
+
+			findings, err := buildpack_config.Lint(config, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(findings).To(ContainElement(versionology.LintFinding{
+				Severity: versionology.SeverityError,
+				ID:       "distro",
+				Message:  "dependency 3.0.0 is duplicated for target linux/amd64 and distros ubuntu@24.04",
+			}))
+			Expect(findings).To(HaveLen(6))
+		})
+
+		context("failure cases", func() {
+			it("will return error when a version is not valid semver", func() {
+				config.Metadata.Dependencies = append(config.Metadata.Dependencies, cargo.ConfigMetadataDependency{
+					ID:      "invalid",
+					Version: "not valid",
+				})
+
+				_, err := buildpack_config.Lint(config, nil)
+				Expect(err).To(MatchError(ContainSubstring("unable to lint invalid")))
+			})
+		})
+	})
+}
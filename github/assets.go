@@ -0,0 +1,86 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/paketo-buildpacks/libdependency/collections"
+	"github.com/paketo-buildpacks/libdependency/retrieve"
+)
+
+type GithubReleaseAssetDTO struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+	Size               int64  `json:"size"`
+	Digest             string `json:"digest,omitempty"`
+}
+
+// Checksum will return the digest of the asset as provided by GitHub (e.g. "sha256:abc..."),
+// which is the format expected in the checksum field of a dependency. It returns "" when GitHub did not provide one.
+func (a GithubReleaseAssetDTO) Checksum() string {
+	return a.Digest
+}
+
+// SHA256 will return the hex encoded SHA256 of the asset, or "" when GitHub did not provide a SHA256 digest
+func (a GithubReleaseAssetDTO) SHA256() string {
+	if algorithm, hash, ok := strings.Cut(a.Digest, ":"); ok && algorithm == "sha256" {
+		return hash
+	}
+	return ""
+}
+
+// GetReleaseAssets will return every asset of the release with the given ID
+// as documented by https://docs.github.com/en/rest/releases/assets#list-release-assets
+func (c *Client) GetReleaseAssets(org, repo string, releaseID int64) ([]GithubReleaseAssetDTO, error) {
+	return getAllPages[GithubReleaseAssetDTO](c, fmt.Sprintf("repos/%s/%s/releases/%d/assets", org, repo, releaseID))
+}
+
+// AssetMatcher will select the asset of a Release for a retrieve.Platform
+type AssetMatcher struct {
+	pattern string
+}
+
+// NewAssetMatcher will return an AssetMatcher for the given pattern, which is a regular expression that must match
+// the whole asset name. The placeholders {os}, {arch} and {version} are replaced by the (escaped) OS and architecture of
+// the platform and the version of the release. For example:
+//
+//	node-v{version}-{os}-{arch}\.tar\.gz
+//
+// Use a retrieve.TransformsPlatformsFunc to translate the platforms when the asset names use other architecture names.
+func NewAssetMatcher(pattern string) AssetMatcher {
+	return AssetMatcher{pattern: pattern}
+}
+
+// Match will return the only asset of the release that matches the pattern for the platform,
+// and an error when there is no such asset or more than one
+func (m AssetMatcher) Match(release Release, platform retrieve.Platform) (GithubReleaseAssetDTO, error) {
+	pattern := strings.NewReplacer(
+		"{os}", regexp.QuoteMeta(platform.OS),
+		"{arch}", regexp.QuoteMeta(platform.Arch),
+		"{version}", regexp.QuoteMeta(release.Version().String()),
+	).Replace(m.pattern)
+
+	regex, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", pattern))
+	if err != nil {
+		return GithubReleaseAssetDTO{}, fmt.Errorf("invalid asset pattern: %w", err)
+	}
+
+	matches := collections.FilterFunc(release.Assets, func(asset GithubReleaseAssetDTO) bool {
+		return regex.MatchString(asset.Name)
+	})
+
+	switch len(matches) {
+	case 0:
+		return GithubReleaseAssetDTO{}, fmt.Errorf("no asset of release %s matches %s for platform %s/%s",
+			release.Version().String(), pattern, platform.OS, platform.Arch)
+	case 1:
+		return matches[0], nil
+	default:
+		names := collections.TransformFunc(matches, func(asset GithubReleaseAssetDTO) string {
+			return asset.Name
+		})
+		return GithubReleaseAssetDTO{}, fmt.Errorf("multiple assets of release %s match %s for platform %s/%s: [%s]",
+			release.Version().String(), pattern, platform.OS, platform.Arch, strings.Join(names, ", "))
+	}
+}
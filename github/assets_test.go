@@ -0,0 +1,78 @@
+package github_test
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	. "github.com/paketo-buildpacks/libdependency/github"
+	"github.com/paketo-buildpacks/libdependency/retrieve"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testAssets(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	context("GithubReleaseAssetDTO", func() {
+		it("will return the checksum and the SHA256 from the digest", func() {
+			asset := GithubReleaseAssetDTO{Digest: "sha256:abc123"}
+			Expect(asset.Checksum()).To(Equal("sha256:abc123"))
+			Expect(asset.SHA256()).To(Equal("abc123"))
+		})
+
+		it("will return empty values without a digest", func() {
+			asset := GithubReleaseAssetDTO{}
+			Expect(asset.Checksum()).To(BeEmpty())
+			Expect(asset.SHA256()).To(BeEmpty())
+		})
+
+		it("will not return a SHA256 for other algorithms", func() {
+			asset := GithubReleaseAssetDTO{Digest: "sha512:abc123"}
+			Expect(asset.SHA256()).To(BeEmpty())
+		})
+	})
+
+	context("AssetMatcher", func() {
+		release := NewRelease(GithubReleaseNamesDTO{
+			TagName: "v1.2.3",
+			Assets: []GithubReleaseAssetDTO{
+				{Name: "tool-1.2.3-linux-amd64.tar.gz", BrowserDownloadURL: "https://example.com/linux-amd64", Digest: "sha256:aaa"},
+				{Name: "tool-1.2.3-linux-amd64.tar.gz.sig", BrowserDownloadURL: "https://example.com/linux-amd64.sig"},
+				{Name: "tool-1.2.3-linux-arm64.tar.gz", BrowserDownloadURL: "https://example.com/linux-arm64", Digest: "sha256:bbb"},
+				{Name: "tool-1.2.3-linux-arm64.zip", BrowserDownloadURL: "https://example.com/linux-arm64.zip"},
+			},
+		}, semver.MustParse("1.2.3"))
+
+		it("will match the asset for the platform", func() {
+			asset, err := NewAssetMatcher(`tool-{version}-{os}-{arch}\.tar\.gz`).Match(release, retrieve.Platform{OS: "linux", Arch: "arm64"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(asset.BrowserDownloadURL).To(Equal("https://example.com/linux-arm64"))
+			Expect(asset.Checksum()).To(Equal("sha256:bbb"))
+		})
+
+		it("will match the whole asset name", func() {
+			asset, err := NewAssetMatcher(`tool-{version}-{os}-{arch}\.tar\.gz`).Match(release, retrieve.Platform{OS: "linux", Arch: "amd64"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(asset.Name).To(Equal("tool-1.2.3-linux-amd64.tar.gz"))
+		})
+
+		it("will return an error when no asset matches", func() {
+			_, err := NewAssetMatcher(`tool-{version}-{os}-{arch}\.tar\.gz`).Match(release, retrieve.Platform{OS: "windows", Arch: "amd64"})
+			Expect(err).To(MatchError(ContainSubstring("no asset of release 1.2.3 matches")))
+			Expect(err).To(MatchError(ContainSubstring("for platform windows/amd64")))
+		})
+
+		it("will return an error when multiple assets match", func() {
+			_, err := NewAssetMatcher(`tool-{version}-{os}-{arch}\..*`).Match(release, retrieve.Platform{OS: "linux", Arch: "arm64"})
+			Expect(err).To(MatchError(ContainSubstring("multiple assets of release 1.2.3 match")))
+			Expect(err).To(MatchError(ContainSubstring("[tool-1.2.3-linux-arm64.tar.gz, tool-1.2.3-linux-arm64.zip]")))
+		})
+
+		it("will return an error for an invalid pattern", func() {
+			_, err := NewAssetMatcher(`tool-(`).Match(release, retrieve.Platform{OS: "linux", Arch: "arm64"})
+			Expect(err).To(MatchError(ContainSubstring("invalid asset pattern")))
+		})
+	})
+
+}
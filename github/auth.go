@@ -0,0 +1,231 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/paketo-buildpacks/libdependency/internal/rest"
+)
+
+// Authenticator adds credentials to requests made to the GitHub API
+type Authenticator = rest.Authenticator
+
+// AuthenticatorFunc is an Authenticator implemented by a function
+type AuthenticatorFunc = rest.AuthenticatorFunc
+
+// TokenAuth will return an Authenticator that sends githubToken as a personal access token,
+// or that sends no credentials when githubToken is empty
+func TokenAuth(githubToken string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		if githubToken != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("token %s", githubToken))
+		}
+		return nil
+	})
+}
+
+// BearerAuth will return an Authenticator that sends githubToken as a bearer token,
+// which works for every kind of token, or that sends no credentials when githubToken is empty
+func BearerAuth(githubToken string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		if githubToken != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", githubToken))
+		}
+		return nil
+	})
+}
+
+// EnvAuth will return an Authenticator that sends the token in $GITHUB_TOKEN, or else in $GH_TOKEN,
+// as a bearer token, or that sends no credentials when neither is set
+func EnvAuth() Authenticator {
+	for _, name := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := os.Getenv(name); token != "" {
+			return BearerAuth(token)
+		}
+	}
+	return BearerAuth("")
+}
+
+const (
+	// appJWTLifetime is how long the JWT authenticating as the app is valid, GitHub allows at most 10 minutes
+	appJWTLifetime = 9 * time.Minute
+	// appJWTClockDrift is how far the JWT is backdated to allow for clock drift with GitHub
+	appJWTClockDrift = time.Minute
+	// installationTokenRefreshMargin is how long before it expires an installation token is refreshed
+	installationTokenRefreshMargin = 5 * time.Minute
+)
+
+// AppAuth is an Authenticator that sends an installation access token of a GitHub App as a bearer token.
+// Installation tokens expire after an hour, so AppAuth mints a new one, by signing a JWT with the private key of
+// the app and exchanging it, whenever the current token is about to expire.
+// See https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/authenticating-as-a-github-app-installation
+type AppAuth struct {
+	appID          int64
+	installationID int64
+	privateKey     *rsa.PrivateKey
+	client         *Client
+
+	mutex     sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewAppAuth will return an AppAuth for the installation of the app, using the PEM encoded private key of the app.
+// The opts configure the client used to exchange tokens, such as WithBaseURL for a GitHub Enterprise Server.
+func NewAppAuth(appID, installationID int64, privateKeyPEM []byte, opts ...ClientOption) (*AppAuth, error) {
+	privateKey, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key of app %d: %w", appID, err)
+	}
+
+	auth := &AppAuth{
+		appID:          appID,
+		installationID: installationID,
+		privateKey:     privateKey,
+		client:         NewClient(opts...),
+	}
+	auth.client.auth = AuthenticatorFunc(auth.authenticateAsApp)
+
+	return auth, nil
+}
+
+// NewAppAuthFromFile will return an AppAuth for the installation of the app, using the private key PEM file of the app
+func NewAppAuthFromFile(appID, installationID int64, privateKeyPath string, opts ...ClientOption) (*AppAuth, error) {
+	privateKeyPEM, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read private key of app %d: %w", appID, err)
+	}
+
+	return NewAppAuth(appID, installationID, privateKeyPEM, opts...)
+}
+
+func (a *AppAuth) Authenticate(req *http.Request) error {
+	token, err := a.Token()
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return nil
+}
+
+// Token will return the current installation access token, minting a new one if it is about to expire
+func (a *AppAuth) Token() (string, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.token != "" && time.Until(a.expiresAt) > installationTokenRefreshMargin {
+		return a.token, nil
+	}
+
+	urlString := a.client.url(fmt.Sprintf("app/installations/%d/access_tokens", a.installationID), "")
+	req, err := a.client.newRequest("POST", urlString, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	res, err := a.client.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to create installation token for app %d at %s with: status code %d", a.appID, urlString, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var installationToken struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	err = json.Unmarshal(body, &installationToken)
+	if err != nil {
+		return "", err
+	}
+
+	a.token = installationToken.Token
+	a.expiresAt = installationToken.ExpiresAt
+
+	return a.token, nil
+}
+
+// authenticateAsApp will authenticate the request as the app itself, with a JWT signed by the private key of the app
+func (a *AppAuth) authenticateAsApp(req *http.Request) error {
+	now := time.Now()
+	jwt, err := signJWT(a.privateKey, map[string]any{
+		"iat": now.Add(-appJWTClockDrift).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": fmt.Sprint(a.appID),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to sign JWT for app %d: %w", a.appID, err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
+	return nil
+}
+
+// signJWT will return the claims as a JWT signed with RS256, as specified by RFC 7519
+func signJWT(privateKey *rsa.PrivateKey, claims map[string]any) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := fmt.Sprintf("%s.%s", base64.RawURLEncoding.EncodeToString(header), base64.RawURLEncoding.EncodeToString(payload))
+
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s.%s", signingInput, base64.RawURLEncoding.EncodeToString(signature)), nil
+}
+
+// parseRSAPrivateKey will parse a PEM encoded RSA private key in PKCS #1 form, as generated by GitHub, or in PKCS #8 form
+func parseRSAPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	if privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return privateKey, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an RSA private key, got %T", key)
+	}
+
+	return privateKey, nil
+}
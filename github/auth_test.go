@@ -0,0 +1,218 @@
+package github_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/paketo-buildpacks/libdependency/github"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testAuth(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	authorization := func(auth Authenticator) string {
+		req, err := http.NewRequest("GET", "https://api.github.com", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(auth.Authenticate(req)).To(Succeed())
+		return req.Header.Get("Authorization")
+	}
+
+	context("TokenAuth", func() {
+		it("will send the token", func() {
+			Expect(authorization(TokenAuth("some-token"))).To(Equal("token some-token"))
+			Expect(authorization(TokenAuth(""))).To(BeEmpty())
+		})
+	})
+
+	context("BearerAuth", func() {
+		it("will send the bearer token", func() {
+			Expect(authorization(BearerAuth("some-token"))).To(Equal("Bearer some-token"))
+			Expect(authorization(BearerAuth(""))).To(BeEmpty())
+		})
+	})
+
+	context("EnvAuth", func() {
+		it("will prefer $GITHUB_TOKEN", func() {
+			t.Setenv("GITHUB_TOKEN", "github-token")
+			t.Setenv("GH_TOKEN", "gh-token")
+			Expect(authorization(EnvAuth())).To(Equal("Bearer github-token"))
+		})
+
+		it("will fall back to $GH_TOKEN", func() {
+			t.Setenv("GITHUB_TOKEN", "")
+			t.Setenv("GH_TOKEN", "gh-token")
+			Expect(authorization(EnvAuth())).To(Equal("Bearer gh-token"))
+		})
+
+		it("will send no credentials without a token", func() {
+			t.Setenv("GITHUB_TOKEN", "")
+			t.Setenv("GH_TOKEN", "")
+			Expect(authorization(EnvAuth())).To(BeEmpty())
+		})
+	})
+
+	context("AppAuth", func() {
+		var (
+			server     *httptest.Server
+			privateKey *rsa.PrivateKey
+			keyPath    string
+			exchanges  int
+			expiresIn  time.Duration
+			claims     map[string]any
+		)
+
+		it.Before(func() {
+			var err error
+			privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+
+			keyPath = filepath.Join(t.TempDir(), "app.pem")
+			Expect(os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{
+				Type:  "RSA PRIVATE KEY",
+				Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+			}), 0600)).To(Succeed())
+
+			exchanges = 0
+			expiresIn = time.Hour
+			claims = nil
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /api/v3/app/installations/7/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+				jwt, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+				if !ok || verifyJWT(jwt, &privateKey.PublicKey, &claims) != nil {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				exchanges++
+				w.WriteHeader(http.StatusCreated)
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"token":      fmt.Sprintf("installation-token-%d", exchanges),
+					"expires_at": time.Now().Add(expiresIn).UTC().Format(time.RFC3339),
+				})
+			})
+			mux.HandleFunc("GET /api/v3/repos/some-org/some-repo/releases", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer installation-token-1" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				writePage(w, r, []GithubReleaseNamesDTO{{TagName: "v1.0.0"}})
+			})
+
+			server = httptest.NewServer(mux)
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		newAppAuth := func(installationID int64) *AppAuth {
+			auth, err := NewAppAuthFromFile(123, installationID, keyPath, WithBaseURL(server.URL+"/api/v3"), WithHTTPClient(server.Client()))
+			Expect(err).NotTo(HaveOccurred())
+			return auth
+		}
+
+		it("will exchange a JWT signed by the app for an installation token", func() {
+			token, err := newAppAuth(7).Token()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("installation-token-1"))
+
+			Expect(claims).To(HaveKeyWithValue("iss", "123"))
+			Expect(claims["iat"]).To(BeNumerically("<", time.Now().Unix()))
+			Expect(claims["exp"]).To(BeNumerically("<=", time.Now().Add(10*time.Minute).Unix()))
+		})
+
+		it("will reuse the installation token until it is about to expire", func() {
+			auth := newAppAuth(7)
+
+			Expect(authorization(auth)).To(Equal("Bearer installation-token-1"))
+			Expect(authorization(auth)).To(Equal("Bearer installation-token-1"))
+			Expect(exchanges).To(Equal(1))
+
+			expiresIn = time.Minute
+			auth = newAppAuth(7)
+
+			Expect(authorization(auth)).To(Equal("Bearer installation-token-2"))
+			Expect(authorization(auth)).To(Equal("Bearer installation-token-3"))
+		})
+
+		it("will authenticate the requests of a client", func() {
+			versions, err := NewClient(WithBaseURL(server.URL+"/api/v3"), WithHTTPClient(server.Client()), WithAuth(newAppAuth(7))).
+				GetAllVersions("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"1.0.0"}))
+		})
+
+		it("will accept a PKCS #8 private key", func() {
+			key, err := x509.MarshalPKCS8PrivateKey(privateKey)
+			Expect(err).NotTo(HaveOccurred())
+
+			auth, err := NewAppAuth(123, 7, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}),
+				WithBaseURL(server.URL+"/api/v3"), WithHTTPClient(server.Client()))
+			Expect(err).NotTo(HaveOccurred())
+
+			token, err := auth.Token()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("installation-token-1"))
+		})
+
+		it("will return an error when the exchange fails", func() {
+			_, err := newAppAuth(8).Token()
+			Expect(err).To(MatchError(ContainSubstring("failed to create installation token for app 123")))
+			Expect(err).To(MatchError(ContainSubstring("status code 404")))
+		})
+
+		it("will return an error for an invalid private key", func() {
+			_, err := NewAppAuth(123, 7, []byte("not a key"))
+			Expect(err).To(MatchError("unable to parse private key of app 123: no PEM data found"))
+		})
+
+		it("will return an error for a missing private key file", func() {
+			_, err := NewAppAuthFromFile(123, 7, filepath.Join(t.TempDir(), "missing.pem"))
+			Expect(err).To(MatchError(ContainSubstring("unable to read private key of app 123")))
+		})
+	})
+}
+
+// verifyJWT will verify the RS256 signature of the JWT and decode its claims
+func verifyJWT(jwt string, publicKey *rsa.PublicKey, claims *map[string]any) error {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed JWT")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hash[:], signature)
+	if err != nil {
+		return err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, claims)
+}
@@ -0,0 +1,147 @@
+package github
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// responseCache stores successful GitHub API responses on disk with their ETag and Last-Modified headers,
+// so they can be revalidated with conditional requests, which do not count against the rate limit when they
+// return 304 Not Modified. Entries are written to a temporary file and renamed into place, so that runs
+// sharing a directory never read a partially written entry.
+type responseCache struct {
+	dir string
+}
+
+type cacheEntry struct {
+	URL          string      `json:"url"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+}
+
+// path will return the path of the entry for the request. Entries are shared by every credential, because tokens
+// such as $GITHUB_TOKEN or installation tokens change on every run. This is safe even when the response differs per
+// credential (e.g. draft releases), because an entry is only served when GitHub answers 304 Not Modified to the
+// credential of the request, i.e. when that credential would receive the same response.
+func (c responseCache) path(req *http.Request) string {
+	hash := sha256.New()
+	for _, value := range []string{req.URL.String(), req.Header.Get("Accept")} {
+		_, _ = fmt.Fprintf(hash, "%s\n", value)
+	}
+	return filepath.Join(c.dir, hex.EncodeToString(hash.Sum(nil))+".json")
+}
+
+// load will return the entry for the request, or false when there is none or it cannot be read
+func (c responseCache) load(req *http.Request) (cacheEntry, bool) {
+	content, err := os.ReadFile(c.path(req))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+
+	var entry cacheEntry
+	err = json.Unmarshal(content, &entry)
+	if err != nil || entry.URL != req.URL.String() {
+		return cacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// store will save the response for the request, if it can be revalidated later
+func (c responseCache) store(req *http.Request, res *http.Response, body []byte) error {
+	entry := cacheEntry{
+		URL:          req.URL.String(),
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+		Header:       res.Header,
+		Body:         body,
+	}
+	if entry.ETag == "" && entry.LastModified == "" {
+		return nil
+	}
+
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(c.dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(c.dir, "*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(content)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), c.path(req))
+}
+
+// do will make the request conditional on the cached entry, and serve a 304 Not Modified response from the cache
+func (c responseCache) do(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+	entry, cached := c.load(req)
+	if cached {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached && res.StatusCode == http.StatusNotModified {
+		_ = res.Body.Close()
+		res.StatusCode = http.StatusOK
+		res.Status = http.StatusText(http.StatusOK)
+		res.Header = entry.Header
+		res.Body = io.NopCloser(bytes.NewReader(entry.Body))
+		res.ContentLength = int64(len(entry.Body))
+		return res, nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return res, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	// The cache only saves requests, so failing to write to it must not fail the run
+	err = c.store(req, res, body)
+	if err != nil {
+		fmt.Printf("Unable to cache response of %s: %s\n", req.URL, err)
+	}
+
+	return res, nil
+}
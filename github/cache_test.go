@@ -0,0 +1,173 @@
+package github_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	. "github.com/paketo-buildpacks/libdependency/github"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testCache(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	var (
+		server      *httptest.Server
+		cacheDir    string
+		releases    []GithubReleaseNamesDTO
+		etag        string
+		mutex       sync.Mutex
+		conditional []string
+		full        int
+	)
+
+	it.Before(func() {
+		cacheDir = t.TempDir()
+		releases = nil
+		etag = `"v1"`
+		conditional = nil
+		full = 0
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			// the draft token can see a draft release, so it gets a different response and ETag
+			if r.Header.Get("Authorization") == "token draft-token" && r.Header.Get("If-None-Match") != `"with-drafts"` {
+				full++
+				w.Header().Set("ETag", `"with-drafts"`)
+				writePage(w, r, append(releases, GithubReleaseNamesDTO{TagName: "v2.0.0", Draft: true}))
+				return
+			}
+
+			if r.Header.Get("If-None-Match") == etag {
+				conditional = append(conditional, r.URL.Query().Get("page"))
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			full++
+			w.Header().Set("ETag", etag)
+			writePage(w, r, releases)
+		}))
+
+		for i := 0; i < 150; i++ {
+			releases = append(releases, GithubReleaseNamesDTO{TagName: fmt.Sprintf("v1.0.%d", i)})
+		}
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	newClient := func() *Client {
+		return NewClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithCacheDir(cacheDir))
+	}
+
+	it("will serve unchanged pages from the cache", func() {
+		versions, err := newClient().GetAllVersions("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(150))
+		Expect(full).To(Equal(2))
+		Expect(conditional).To(BeEmpty())
+
+		versions, err = newClient().GetAllVersions("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(150))
+		Expect(full).To(Equal(2))
+		Expect(conditional).To(Equal([]string{"", "2"}))
+	})
+
+	it("will replace changed pages in the cache", func() {
+		_, err := newClient().GetAllVersions("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+
+		etag = `"v2"`
+		releases = append(releases, GithubReleaseNamesDTO{TagName: "v2.0.0"})
+
+		versions, err := newClient().GetAllVersions("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(151))
+		Expect(full).To(Equal(4))
+
+		versions, err = newClient().GetAllVersions("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(151))
+		Expect(full).To(Equal(4))
+	})
+
+	it("will reuse entries across tokens", func() {
+		_, err := NewClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithCacheDir(cacheDir), WithToken("some-token")).
+			GetAllVersions("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+
+		versions, err := NewClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithCacheDir(cacheDir), WithToken("other-token")).
+			GetAllVersions("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(150))
+		Expect(full).To(Equal(2))
+		Expect(conditional).To(Equal([]string{"", "2"}))
+	})
+
+	it("will not serve an entry to a token that would get a different response", func() {
+		_, err := newClient().GetAllVersions("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+
+		versions, err := NewClient(WithBaseURL(server.URL), WithHTTPClient(server.Client()), WithCacheDir(cacheDir), WithToken("draft-token")).
+			GetAllVersions("some-org", "some-repo", WithDrafts(true))()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(151))
+		Expect(full).To(Equal(4))
+		Expect(conditional).To(BeEmpty())
+	})
+
+	it("will ignore unreadable entries", func() {
+		_, err := newClient().GetAllVersions("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+
+		entries, err := filepath.Glob(filepath.Join(cacheDir, "*.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+		for _, entry := range entries {
+			Expect(os.WriteFile(entry, []byte("not json"), 0600)).To(Succeed())
+		}
+
+		versions, err := newClient().GetAllVersions("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(150))
+		Expect(conditional).To(BeEmpty())
+	})
+
+	it("will be safe for concurrent clients sharing the directory", func() {
+		var wg sync.WaitGroup
+		errs := make(chan error, 10)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				versions, err := newClient().GetAllVersions("some-org", "some-repo")()
+				if err == nil && len(versions) != 150 {
+					err = fmt.Errorf("expected 150 versions, got %d", len(versions))
+				}
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		temporary, err := filepath.Glob(filepath.Join(cacheDir, "*.tmp"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(temporary).To(BeEmpty())
+	})
+}
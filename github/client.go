@@ -0,0 +1,178 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultBaseURL is the base URL of the public GitHub REST API
+const DefaultBaseURL = "https://api.github.com"
+
+// Client queries the GitHub REST API of github.com or of a GitHub Enterprise Server
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	auth       Authenticator
+	graphQLURL string
+	maxPages   int
+	cache      *responseCache
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithBaseURL will query the API at baseURL instead of DefaultBaseURL,
+// e.g. https://github.example.com/api/v3 for a GitHub Enterprise Server
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithGraphQLURL will send GraphQL queries to graphQLURL. By default, the GraphQL endpoint is derived from the base URL,
+// e.g. https://api.github.com/graphql or https://github.example.com/api/graphql for a GitHub Enterprise Server.
+func WithGraphQLURL(graphQLURL string) ClientOption {
+	return func(c *Client) {
+		c.graphQLURL = graphQLURL
+	}
+}
+
+// WithHTTPClient will make requests with httpClient. By default, requests are made by an http.Client
+// using a RateLimitTransport, which httpClient should also use to retry requests that hit rate limits.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserAgent will send userAgent as the User-Agent of every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithAuth will use auth to add credentials to every request
+func WithAuth(auth Authenticator) ClientOption {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// WithToken will send githubToken as a personal access token with every request
+func WithToken(githubToken string) ClientOption {
+	return WithAuth(TokenAuth(githubToken))
+}
+
+// WithMaxPages will stop listing after maxPages pages, which limits the number of requests for very large repos
+// at the cost of only returning the items on those pages. By default, every page is requested.
+func WithMaxPages(maxPages int) ClientOption {
+	return func(c *Client) {
+		c.maxPages = maxPages
+	}
+}
+
+// WithCacheDir will store responses in dir and revalidate them with conditional requests, so that unchanged pages
+// are served from dir without using up the rate limit. The directory may be shared by concurrent runs.
+func WithCacheDir(dir string) ClientOption {
+	return func(c *Client) {
+		c.cache = &responseCache{dir: dir}
+	}
+}
+
+// NewClient will return a Client for DefaultBaseURL without credentials, unless configured otherwise by opts
+func NewClient(opts ...ClientOption) *Client {
+	client := &Client{
+		baseURL:    DefaultBaseURL,
+		httpClient: &http.Client{Transport: NewRateLimitTransport(nil)},
+		userAgent:  "paketo-buildpacks/libdependency",
+		auth:       TokenAuth(""),
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	if client.graphQLURL == "" {
+		if baseURL, ok := strings.CutSuffix(client.baseURL, "/api/v3"); ok {
+			client.graphQLURL = baseURL + "/api/graphql"
+		} else {
+			client.graphQLURL = client.baseURL + "/graphql"
+		}
+	}
+
+	return client
+}
+
+// url will return the URL of the given API path and query
+func (c *Client) url(path, query string) string {
+	urlString := fmt.Sprintf("%s/%s", c.baseURL, strings.TrimPrefix(path, "/"))
+	if query != "" {
+		urlString = fmt.Sprintf("%s?%s", urlString, query)
+	}
+	return urlString
+}
+
+// get will make an authenticated GET request for the given URL
+func (c *Client) get(urlString string) (*http.Response, error) {
+	req, err := c.newRequest("GET", urlString, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	if c.cache != nil {
+		return c.cache.do(c.httpClient, req)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// getJSON will make an authenticated GET request for the given URL, decode the body of a successful response into v
+// and return the headers of the response
+func (c *Client) getJSON(urlString string, v any) (http.Header, error) {
+	res, err := c.get(urlString)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query url %s with: status code %d", urlString, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Header, nil
+}
+
+// newRequest will return an authenticated request for the given URL
+func (c *Client) newRequest(method, urlString string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, urlString, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	err = c.auth.Authenticate(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to authenticate request to %s: %w", urlString, err)
+	}
+
+	return req, nil
+}
@@ -0,0 +1,245 @@
+package github_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	. "github.com/paketo-buildpacks/libdependency/github"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testClient(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	var (
+		server   *httptest.Server
+		requests []*http.Request
+		releases []GithubReleaseNamesDTO
+		tags     []GithubTagDTO
+		assets   []GithubReleaseAssetDTO
+	)
+
+	it.Before(func() {
+		requests = nil
+		releases = nil
+		tags = nil
+		assets = nil
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/v3/repos/some-org/some-repo/releases", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			writePage(w, r, releases)
+		})
+		mux.HandleFunc("GET /api/v3/repos/some-org/some-repo/tags", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			writePage(w, r, tags)
+		})
+		mux.HandleFunc("GET /api/v3/repos/some-org/some-repo/releases/42/assets", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			writePage(w, r, assets)
+		})
+		mux.HandleFunc("GET /api/v3/repos/some-org/missing-repo/releases", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		server = httptest.NewServer(mux)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	newClient := func(opts ...ClientOption) *Client {
+		return NewClient(append([]ClientOption{WithBaseURL(server.URL + "/api/v3/"), WithHTTPClient(server.Client())}, opts...)...)
+	}
+
+	context("GetAllVersions", func() {
+		it("will return the releases newest first", func() {
+			releases = []GithubReleaseNamesDTO{
+				{Name: "1.0.0", TagName: "v1.0.0"},
+				{Name: "2.0.0", TagName: "v2.0.0"},
+				{Name: "Not a version", TagName: "not-a-version"},
+			}
+
+			versions, err := newClient().GetAllVersions("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"2.0.0", "1.0.0"}))
+		})
+
+		it("will exclude drafts and prereleases by default", func() {
+			releases = []GithubReleaseNamesDTO{
+				{Name: "2.0.0", TagName: "v2.0.0"},
+				{Name: "2.1.0-rc.1", TagName: "v2.1.0-rc.1", Prerelease: true},
+				{Name: "3.0.0", TagName: "v3.0.0", Draft: true},
+			}
+
+			versions, err := newClient().GetAllVersions("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"2.0.0"}))
+		})
+
+		it("will include drafts and prereleases with the options", func() {
+			releases = []GithubReleaseNamesDTO{
+				{Name: "2.0.0", TagName: "v2.0.0"},
+				{Name: "2.1.0-rc.1", TagName: "v2.1.0-rc.1", Prerelease: true},
+				{Name: "3.0.0", TagName: "v3.0.0", Draft: true},
+			}
+
+			versions, err := newClient().GetAllVersions("some-org", "some-repo", WithPrereleases(true))()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"2.1.0-rc.1", "2.0.0"}))
+
+			versions, err = newClient().GetAllVersions("some-org", "some-repo", WithDrafts(true))()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"3.0.0", "2.0.0"}))
+
+			versions, err = newClient().GetAllVersions("some-org", "some-repo", WithPrereleases(true), WithDrafts(true))()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"3.0.0", "2.1.0-rc.1", "2.0.0"}))
+		})
+
+		it("will request every page", func() {
+			for i := 0; i < 150; i++ {
+				releases = append(releases, GithubReleaseNamesDTO{TagName: fmt.Sprintf("v1.0.%d", i)})
+			}
+
+			versions, err := newClient().GetAllVersions("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(150))
+			Expect(requests).To(HaveLen(2))
+			Expect(requests[1].URL.Query().Get("page")).To(Equal("2"))
+		})
+
+		it("will not request an empty page when the count is a multiple of the page size", func() {
+			for i := 0; i < 100; i++ {
+				releases = append(releases, GithubReleaseNamesDTO{TagName: fmt.Sprintf("v1.0.%d", i)})
+			}
+
+			versions, err := newClient().GetAllVersions("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(100))
+			Expect(requests).To(HaveLen(1))
+		})
+
+		it("will stop after the maximum number of pages", func() {
+			for i := 0; i < 250; i++ {
+				releases = append(releases, GithubReleaseNamesDTO{TagName: fmt.Sprintf("v1.0.%d", i)})
+			}
+
+			versions, err := newClient(WithMaxPages(2)).GetAllVersions("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(200))
+			Expect(requests).To(HaveLen(2))
+		})
+
+		it("will send the user agent and credentials", func() {
+			_, err := newClient(WithUserAgent("some-agent"), WithToken("some-token")).GetAllVersions("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Header.Get("User-Agent")).To(Equal("some-agent"))
+			Expect(requests[0].Header.Get("Authorization")).To(Equal("token some-token"))
+			Expect(requests[0].Header.Get("Accept")).To(Equal("application/vnd.github.v3+json"))
+		})
+
+		it("will send no credentials without a token", func() {
+			_, err := newClient().GetAllVersions("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests[0].Header.Get("Authorization")).To(BeEmpty())
+		})
+
+		it("will return an error when authentication fails", func() {
+			_, err := newClient(WithAuth(AuthenticatorFunc(func(req *http.Request) error {
+				return fmt.Errorf("no credentials")
+			}))).GetAllVersions("some-org", "some-repo")()
+			Expect(err).To(MatchError(ContainSubstring("unable to authenticate request to")))
+			Expect(err).To(MatchError(ContainSubstring("no credentials")))
+		})
+
+		it("will return an error for an unsuccessful response", func() {
+			_, err := newClient().GetAllVersions("some-org", "missing-repo")()
+			Expect(err).To(MatchError(ContainSubstring("/api/v3/repos/some-org/missing-repo/releases?per_page=100 with: status code 404")))
+		})
+	})
+
+	context("GetAllVersionsFromTags", func() {
+		it("will return the tags newest first", func() {
+			tags = []GithubTagDTO{
+				{Name: "v1.0.0"},
+				{Name: "v1.1.0"},
+			}
+
+			versions, err := newClient().GetAllVersionsFromTags("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"1.1.0", "1.0.0"}))
+		})
+	})
+
+	context("GetAllVersionsFromReleasesOrTags", func() {
+		it("will use the releases when there are any", func() {
+			releases = []GithubReleaseNamesDTO{{TagName: "v2.0.0"}}
+			tags = []GithubTagDTO{{Name: "v1.0.0"}}
+
+			versions, err := newClient().GetAllVersionsFromReleasesOrTags("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"2.0.0"}))
+		})
+
+		it("will use the tags when there are no releases", func() {
+			tags = []GithubTagDTO{{Name: "v1.0.0"}}
+
+			versions, err := newClient().GetAllVersionsFromReleasesOrTags("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"1.0.0"}))
+		})
+	})
+
+	context("GetReleaseAssets", func() {
+		it("will return the assets of every page", func() {
+			for i := 0; i < 120; i++ {
+				assets = append(assets, GithubReleaseAssetDTO{Name: fmt.Sprintf("asset-%d", i)})
+			}
+
+			releaseAssets, err := newClient().GetReleaseAssets("some-org", "some-repo", 42)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(releaseAssets).To(HaveLen(120))
+			Expect(releaseAssets[119].Name).To(Equal("asset-119"))
+			Expect(requests).To(HaveLen(2))
+		})
+	})
+}
+
+// writePage will respond with the page of items requested by the per_page and page query parameters,
+// with a Link header to the next page when there is one
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil {
+		perPage = 30
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil {
+		page = 1
+	}
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+
+	if end < len(items) {
+		next := *r.URL
+		next.Scheme = "http"
+		next.Host = r.Host
+		query := next.Query()
+		query.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = query.Encode()
+
+		w.Header().Add("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="first"`, next.String(), r.URL.Path))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(items[start:end])
+}
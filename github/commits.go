@@ -0,0 +1,87 @@
+package github
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/libdependency/retrieve"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+)
+
+// pseudoVersionTimeFormat is the UTC timestamp format of pseudo-versions, which sorts chronologically
+const pseudoVersionTimeFormat = "20060102150405"
+
+type GithubRepositoryDTO struct {
+	DefaultBranch string `json:"default_branch"`
+}
+
+type GithubBranchCommitDTO struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+// Commit is a versionology.VersionFetcher for a commit, versioned by its pseudo-version.
+// It also implements versionology.ReleaseDateFetcher using the commit date.
+type Commit struct {
+	SHA     string
+	Date    time.Time
+	HTMLURL string
+	version *semver.Version
+}
+
+// NewCommit will return a Commit for the given commit SHA and commit date
+func NewCommit(sha string, date time.Time, htmlURL string) Commit {
+	return Commit{
+		SHA:     sha,
+		Date:    date,
+		HTMLURL: htmlURL,
+		version: PseudoVersion(sha, date),
+	}
+}
+
+func (c Commit) Version() *semver.Version {
+	return c.version
+}
+
+func (c Commit) ReleaseDate() time.Time {
+	return c.Date
+}
+
+// PseudoVersion will return the Go-style pseudo-version of a commit, e.g. 0.0.0-20250102030405-abcdef123456,
+// made of its UTC commit date and the first 12 characters of its SHA. Pseudo-versions of later commits are greater.
+// Pseudo-versions are prereleases, so they only match constraints with a prerelease, such as `>=0.0.0-0`.
+func PseudoVersion(sha string, date time.Time) *semver.Version {
+	return semver.New(0, 0, 0, fmt.Sprintf("%s-%s", date.UTC().Format(pseudoVersionTimeFormat), sha[:min(len(sha), 12)]), "")
+}
+
+// GetAllVersionsFromDefaultBranch will return a libdependency.VersionFetcherFunc that returns the head commit of the
+// default branch of a given GitHub org/repo as a Commit, for repos that publish neither releases nor tags.
+func GetAllVersionsFromDefaultBranch(githubToken, org, repo string) retrieve.GetAllVersionsFunc {
+	return NewClient(WithToken(githubToken)).GetAllVersionsFromDefaultBranch(org, repo)
+}
+
+// GetAllVersionsFromDefaultBranch will return a libdependency.VersionFetcherFunc that returns the head commit of the
+// default branch of a given GitHub org/repo as a Commit, for repos that publish neither releases nor tags.
+func (c *Client) GetAllVersionsFromDefaultBranch(org, repo string) retrieve.GetAllVersionsFunc {
+	return func() (versionology.VersionFetcherArray, error) {
+		var repository GithubRepositoryDTO
+		_, err := c.getJSON(c.url(fmt.Sprintf("repos/%s/%s", org, repo), ""), &repository)
+		if err != nil {
+			return versionology.NewVersionFetcherArray(), err
+		}
+
+		var commit GithubBranchCommitDTO
+		_, err = c.getJSON(c.url(fmt.Sprintf("repos/%s/%s/commits/%s", org, repo, repository.DefaultBranch), ""), &commit)
+		if err != nil {
+			return versionology.NewVersionFetcherArray(), err
+		}
+
+		return versionology.VersionFetcherArray{NewCommit(commit.SHA, commit.Commit.Committer.Date, commit.HTMLURL)}, nil
+	}
+}
@@ -0,0 +1,105 @@
+package github_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/paketo-buildpacks/libdependency/github"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testCommits(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	context("PseudoVersion", func() {
+		it("will use the UTC commit date and the first 12 characters of the SHA", func() {
+			date := time.Date(2025, 1, 2, 4, 4, 5, 0, time.FixedZone("CET", 3600))
+			version := PseudoVersion("012345678901abcdef0123456789abcdef012345", date)
+			Expect(version.String()).To(Equal("0.0.0-20250102030405-012345678901"))
+		})
+
+		it("will sort later commits after earlier commits", func() {
+			earlier := PseudoVersion("ffffffffffffffffffffffffffffffffffffffff", time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC))
+			later := PseudoVersion("0000000000000000000000000000000000000000", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+			Expect(later.GreaterThan(earlier)).To(BeTrue())
+		})
+	})
+
+	context("GetAllVersionsFromDefaultBranch", func() {
+		var server *httptest.Server
+
+		it.Before(func() {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /repos/some-org/some-repo", func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(map[string]any{"default_branch": "main"})
+			})
+			mux.HandleFunc("GET /repos/some-org/some-repo/commits/main", func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"sha":      "abcdef1234567890abcdef1234567890abcdef12",
+					"html_url": "https://github.com/some-org/some-repo/commit/abcdef1234567890abcdef1234567890abcdef12",
+					"commit": map[string]any{
+						"committer": map[string]any{"date": "2025-01-02T03:04:05Z"},
+					},
+				})
+			})
+			server = httptest.NewServer(mux)
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		it("will return the head commit of the default branch", func() {
+			versions, err := NewClient(WithBaseURL(server.URL), WithHTTPClient(server.Client())).
+				GetAllVersionsFromDefaultBranch("some-org", "some-repo")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(1))
+
+			commit, ok := versions[0].(Commit)
+			Expect(ok).To(BeTrue())
+			Expect(commit.Version().String()).To(Equal("0.0.0-20250102030405-abcdef123456"))
+			Expect(commit.SHA).To(Equal("abcdef1234567890abcdef1234567890abcdef12"))
+			Expect(commit.ReleaseDate()).To(Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+			Expect(commit.HTMLURL).To(ContainSubstring("/commit/abcdef1234567890abcdef1234567890abcdef12"))
+		})
+
+		it("will return an error when the repo is missing", func() {
+			_, err := NewClient(WithBaseURL(server.URL), WithHTTPClient(server.Client())).
+				GetAllVersionsFromDefaultBranch("some-org", "missing-repo")()
+			Expect(err).To(MatchError(ContainSubstring("/repos/some-org/missing-repo with: status code 404")))
+		})
+	})
+
+	context("with constraints", func() {
+		it("will find a newer commit than the existing one", func() {
+			constraint, err := versionology.NewConstraint(cargo.ConfigMetadataDependencyConstraint{
+				Constraint: "~0.0.0-0",
+				ID:         "some-id",
+				Patches:    1,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			existing := NewCommit("1111111111111111111111111111111111111111", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "")
+			upstream := NewCommit("2222222222222222222222222222222222222222", time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), "")
+
+			versions := versionology.FilterUpstreamVersionsByConstraints("some-id",
+				versionology.VersionFetcherArray{upstream},
+				[]versionology.Constraint{constraint},
+				versionology.VersionFetcherArray{existing})
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"0.0.0-20250201000000-222222222222"}))
+
+			versions = versionology.FilterUpstreamVersionsByConstraints("some-id",
+				versionology.VersionFetcherArray{existing},
+				[]versionology.Constraint{constraint},
+				versionology.VersionFetcherArray{existing})
+			Expect(versions).To(BeEmpty())
+		})
+	})
+}
@@ -1,104 +1,173 @@
 package github
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"sort"
-	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
-	"github.com/paketo-buildpacks/libdependency/collections"
+	"github.com/paketo-buildpacks/libdependency/internal/rest"
 	"github.com/paketo-buildpacks/libdependency/retrieve"
 	"github.com/paketo-buildpacks/libdependency/versionology"
 )
 
 type GithubReleaseNamesDTO struct {
-	Name    string `json:"name"`
-	TagName string `json:"tag_name"`
+	ID          int64                   `json:"id"`
+	Name        string                  `json:"name"`
+	TagName     string                  `json:"tag_name"`
+	Prerelease  bool                    `json:"prerelease"`
+	Draft       bool                    `json:"draft"`
+	PublishedAt time.Time               `json:"published_at"`
+	HTMLURL     string                  `json:"html_url"`
+	Assets      []GithubReleaseAssetDTO `json:"assets"`
+}
+
+// Release is a versionology.VersionFetcher for a GitHub release, which exposes the release metadata
+// and also implements versionology.ReleaseDateFetcher using the release's `published_at`
+type Release struct {
+	GithubReleaseNamesDTO
+	version *semver.Version
+}
+
+// NewRelease will return a Release for the given release and its sanitized version
+func NewRelease(release GithubReleaseNamesDTO, version *semver.Version) Release {
+	return Release{
+		GithubReleaseNamesDTO: release,
+		version:               version,
+	}
+}
+
+func (r Release) Version() *semver.Version {
+	return r.version
+}
+
+func (r Release) ReleaseDate() time.Time {
+	return r.PublishedAt
 }
 
 // SanitizeGithubReleaseName will determine whether to use the name or the tag as the semver version
 func SanitizeGithubReleaseName(release GithubReleaseNamesDTO) (*semver.Version, error) {
-	if version, err := semver.NewVersion(strings.TrimSpace(release.Name)); err != nil {
-		return semver.NewVersion(strings.TrimSpace(release.TagName))
+	return ExtractGithubReleaseVersion(release, versionology.DefaultVersionExtractor)
+}
+
+// ExtractGithubReleaseVersion will use extractor on the name, and then on the tag, to determine the semver version
+func ExtractGithubReleaseVersion(release GithubReleaseNamesDTO, extractor versionology.VersionExtractor) (*semver.Version, error) {
+	if version, err := extractor(release.Name); err != nil {
+		return extractor(release.TagName)
 	} else {
 		return version, nil
 	}
 }
 
 // GetAllVersions will return a libdependency.VersionFetcherFunc that can retrieve all versions for a given
-// GitHub org/repo.
-func GetAllVersions(githubToken, org, repo string) retrieve.GetAllVersionsFunc {
+// GitHub org/repo. Releases that GitHub flags as drafts or prereleases are excluded unless the options include
+// them, see WithDrafts and WithPrereleases.
+func GetAllVersions(githubToken, org, repo string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return NewClient(WithToken(githubToken)).GetAllVersions(org, repo, opts...)
+}
+
+// GetAllVersionsFromTags will return a libdependency.VersionFetcherFunc that can retrieve all versions for a given
+// GitHub org/repo from its tags, for repos that do not publish releases.
+func GetAllVersionsFromTags(githubToken, org, repo string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return NewClient(WithToken(githubToken)).GetAllVersionsFromTags(org, repo, opts...)
+}
+
+// GetAllVersionsFromReleasesOrTags will return a libdependency.VersionFetcherFunc that can retrieve all versions for
+// a given GitHub org/repo from its releases, falling back to its tags when there are no releases with a valid version.
+func GetAllVersionsFromReleasesOrTags(githubToken, org, repo string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return NewClient(WithToken(githubToken)).GetAllVersionsFromReleasesOrTags(org, repo, opts...)
+}
+
+// GetAllVersions will return a libdependency.VersionFetcherFunc that can retrieve all versions for a given
+// GitHub org/repo. Releases that GitHub flags as drafts or prereleases are excluded unless the options include
+// them, see WithDrafts and WithPrereleases.
+func (c *Client) GetAllVersions(org, repo string, opts ...Option) retrieve.GetAllVersionsFunc {
 	return func() (versionology.VersionFetcherArray, error) {
-		return getReleasesFromGithub(githubToken, org, repo)
+		return c.getReleases(org, repo, newSourceOptions(opts))
 	}
 }
 
-// getReleasesFromGithub will return all semver-compatible versions from the releases of the given repo
-// as documented by https://docs.github.com/en/rest/releases/releases#list-releases
-func getReleasesFromGithub(githubToken, org, repo string) (versionology.VersionFetcherArray, error) {
-	client := &http.Client{}
-
-	perPage := 100
+// GetAllVersionsFromTags will return a libdependency.VersionFetcherFunc that can retrieve all versions for a given
+// GitHub org/repo from its tags, for repos that do not publish releases.
+func (c *Client) GetAllVersionsFromTags(org, repo string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return func() (versionology.VersionFetcherArray, error) {
+		return c.getTags(org, repo, newSourceOptions(opts))
+	}
+}
 
-	allVersions := make([]*semver.Version, 0)
+// GetAllVersionsFromReleasesOrTags will return a libdependency.VersionFetcherFunc that can retrieve all versions for
+// a given GitHub org/repo from its releases, falling back to its tags when there are no releases with a valid version.
+func (c *Client) GetAllVersionsFromReleasesOrTags(org, repo string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return func() (versionology.VersionFetcherArray, error) {
+		options := newSourceOptions(opts)
 
-	for page := 1; ; page++ {
-		urlString := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases?per_page=%d&page=%d", org, repo, perPage, page)
-		req, err := http.NewRequest("GET", urlString, nil)
-		if err != nil {
-			return versionology.NewVersionFetcherArray(), err
+		versions, err := c.getReleases(org, repo, options)
+		if err != nil || len(versions) > 0 {
+			return versions, err
 		}
 
-		req.Header.Set("Accept", "application/vnd.github.v3+json")
-		if githubToken != "" {
-			req.Header.Set("Authorization", fmt.Sprintf("token %s", githubToken))
-		}
+		fmt.Printf("Found no releases of %s/%s, using tags instead\n", org, repo)
+		return c.getTags(org, repo, options)
+	}
+}
 
-		res, err := client.Do(req)
-		if err != nil {
-			return versionology.NewVersionFetcherArray(), err
-		}
+// getReleases will return all semver-compatible versions from the releases of the given repo,
+// excluding drafts and prereleases unless the options include them, as documented by https://docs.github.com/en/rest/releases/releases#list-releases
+func (c *Client) getReleases(org, repo string, options sourceOptions) (versionology.VersionFetcherArray, error) {
+	githubReleaseNames, err := getAllPages[GithubReleaseNamesDTO](c, fmt.Sprintf("repos/%s/%s/releases", org, repo))
+	if err != nil {
+		return versionology.NewVersionFetcherArray(), err
+	}
 
-		if res.StatusCode != http.StatusOK {
-			return versionology.NewVersionFetcherArray(),
-				fmt.Errorf("failed to query url %s with: status code %d", urlString, res.StatusCode)
-		}
+	return newReleaseVersions(githubReleaseNames, org, repo, options), nil
+}
 
-		body, err := io.ReadAll(res.Body)
-		if err != nil {
-			return versionology.NewVersionFetcherArray(), err
+// newReleaseVersions will return the semver-compatible versions of the releases,
+// excluding drafts and prereleases unless the options include them
+func newReleaseVersions(githubReleaseNames []GithubReleaseNamesDTO, org, repo string, options sourceOptions) versionology.VersionFetcherArray {
+	allReleases := make([]Release, 0)
+	for _, release := range githubReleaseNames {
+		if release.Draft && !options.includeDrafts {
+			fmt.Printf("Skipping draft release '%s' with tag '%s' of %s/%s\n", release.Name, release.TagName, org, repo)
+			continue
 		}
 
-		err = res.Body.Close()
-		if err != nil {
-			return versionology.NewVersionFetcherArray(), err
+		if release.Prerelease && !options.includePrereleases {
+			fmt.Printf("Skipping prerelease '%s' with tag '%s' of %s/%s\n", release.Name, release.TagName, org, repo)
+			continue
 		}
 
-		var githubReleaseNames []GithubReleaseNamesDTO
-		err = json.Unmarshal(body, &githubReleaseNames)
-		if err != nil {
-			return versionology.NewVersionFetcherArray(), err
+		if version, err := ExtractGithubReleaseVersion(release, options.versionExtractor); err != nil {
+			fmt.Printf("Skipping release '%s' with tag '%s' of %s/%s: %s\n", release.Name, release.TagName, org, repo, err)
+		} else {
+			allReleases = append(allReleases, NewRelease(release, version))
 		}
+	}
 
-		for _, release := range githubReleaseNames {
-			if version, err := SanitizeGithubReleaseName(release); err == nil {
-				allVersions = append(allVersions, version)
-			}
-		}
+	return versionology.SortedNewestFirst(allReleases)
+}
+
+// getTags will return all semver-compatible versions from the tags of the given repo
+// as documented by https://docs.github.com/en/rest/repos/repos#list-repository-tags
+func (c *Client) getTags(org, repo string, options sourceOptions) (versionology.VersionFetcherArray, error) {
+	githubTags, err := getAllPages[GithubTagDTO](c, fmt.Sprintf("repos/%s/%s/tags", org, repo))
+	if err != nil {
+		return versionology.NewVersionFetcherArray(), err
+	}
 
-		if len(githubReleaseNames) < perPage {
-			break
+	allTags := make([]Tag, 0)
+	for _, tag := range githubTags {
+		if version, err := options.versionExtractor(tag.Name); err != nil {
+			fmt.Printf("Skipping tag '%s' of %s/%s: %s\n", tag.Name, org, repo, err)
+		} else {
+			allTags = append(allTags, NewTag(tag, version))
 		}
 	}
 
-	sort.Slice(allVersions, func(i, j int) bool {
-		return allVersions[i].GreaterThan(allVersions[j])
-	})
+	return versionology.SortedNewestFirst(allTags), nil
+}
 
-	return collections.TransformFunc(allVersions, func(version *semver.Version) versionology.VersionFetcher {
-		return versionology.NewSimpleVersionFetcher(version)
-	}), nil
+// getAllPages will return the items of every page of the given GitHub API path, following the "next" links of the
+// Link header as documented by https://docs.github.com/en/rest/using-the-rest-api/using-pagination-in-the-rest-api
+func getAllPages[T any](c *Client, path string) ([]T, error) {
+	return rest.GetAllPages[T](c.getJSON, c.url(path, "per_page=100"), c.maxPages, path)
 }
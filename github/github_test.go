@@ -1,9 +1,13 @@
 package github_test
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
 	. "github.com/paketo-buildpacks/libdependency/github"
+	"github.com/paketo-buildpacks/libdependency/versionology"
 	"github.com/sclevine/spec"
 
 	. "github.com/onsi/gomega"
@@ -149,4 +153,89 @@ func testGithub(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 	})
+
+	context("SanitizeGithubTagName", func() {
+		it("will return the tag name", func() {
+			version, err := SanitizeGithubTagName(GithubTagDTO{Name: "1.2.3"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version.String()).To(Equal("1.2.3"))
+		})
+
+		it("will strip a leading 'v' and whitespace", func() {
+			version, err := SanitizeGithubTagName(GithubTagDTO{Name: " v2.3.4 "})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version.String()).To(Equal("2.3.4"))
+		})
+
+		context("failure cases", func() {
+			it("will return the error", func() {
+				_, err := SanitizeGithubTagName(GithubTagDTO{Name: "not a semver"})
+				Expect(err).To(MatchError("invalid semantic version"))
+			})
+		})
+	})
+
+	context("GithubReleaseNamesDTO", func() {
+		it("will decode the release metadata", func() {
+			var release GithubReleaseNamesDTO
+			err := json.Unmarshal([]byte(`{
+				"name": "v1.2.3",
+				"tag_name": "v1.2.3",
+				"prerelease": true,
+				"draft": false,
+				"published_at": "2024-06-15T12:00:00Z",
+				"html_url": "https://github.com/org/repo/releases/tag/v1.2.3",
+				"assets": [{
+					"name": "repo-linux-amd64.tar.gz",
+					"browser_download_url": "https://github.com/org/repo/releases/download/v1.2.3/repo-linux-amd64.tar.gz",
+					"size": 1234
+				}]
+			}`), &release)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(release).To(Equal(GithubReleaseNamesDTO{
+				Name:        "v1.2.3",
+				TagName:     "v1.2.3",
+				Prerelease:  true,
+				PublishedAt: time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC),
+				HTMLURL:     "https://github.com/org/repo/releases/tag/v1.2.3",
+				Assets: []GithubReleaseAssetDTO{{
+					Name:               "repo-linux-amd64.tar.gz",
+					BrowserDownloadURL: "https://github.com/org/repo/releases/download/v1.2.3/repo-linux-amd64.tar.gz",
+					Size:               1234,
+				}},
+			}))
+		})
+	})
+
+	context("Release", func() {
+		it("will expose the version and the published date", func() {
+			publishedAt := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+			release := NewRelease(GithubReleaseNamesDTO{
+				Name:        "1.2.3",
+				PublishedAt: publishedAt,
+			}, semver.MustParse("1.2.3"))
+
+			var versionFetcher versionology.VersionFetcher = release
+			Expect(versionFetcher.Version().String()).To(Equal("1.2.3"))
+
+			releaseDateFetcher, ok := versionFetcher.(versionology.ReleaseDateFetcher)
+			Expect(ok).To(BeTrue())
+			Expect(releaseDateFetcher.ReleaseDate()).To(Equal(publishedAt))
+		})
+	})
+
+	context("ExtractGithubReleaseVersion", func() {
+		it("will fall back to the tag", func() {
+			extractor, err := versionology.NewRegexVersionExtractor(`^release-(?P<version>.+)$`)
+			Expect(err).NotTo(HaveOccurred())
+
+			version, err := ExtractGithubReleaseVersion(GithubReleaseNamesDTO{
+				Name:    "Release 1.2.3",
+				TagName: "release-1.2.3",
+			}, extractor)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version.String()).To(Equal("1.2.3"))
+		})
+	})
 }
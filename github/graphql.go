@@ -0,0 +1,186 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/paketo-buildpacks/libdependency/retrieve"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+)
+
+// releasesQuery lists the releases of a repo, newest first, 100 at a time which is the largest page GitHub allows
+const releasesQuery = `query($owner: String!, $name: String!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    releases(first: 100, after: $after, orderBy: {field: CREATED_AT, direction: DESC}) {
+      nodes {
+        databaseId
+        name
+        tagName
+        isPrerelease
+        isDraft
+        publishedAt
+        url
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type graphQLReleasesResponse struct {
+	Data struct {
+		Repository *struct {
+			Releases struct {
+				Nodes []struct {
+					DatabaseID   int64     `json:"databaseId"`
+					Name         string    `json:"name"`
+					TagName      string    `json:"tagName"`
+					IsPrerelease bool      `json:"isPrerelease"`
+					IsDraft      bool      `json:"isDraft"`
+					PublishedAt  time.Time `json:"publishedAt"`
+					URL          string    `json:"url"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"releases"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+// GetAllVersionsFromGraphQL will return a libdependency.VersionFetcherFunc that can retrieve all versions for a given
+// GitHub org/repo, like GetAllVersions but using far fewer requests through the GraphQL API.
+// The GraphQL API requires a token, and does not return release assets.
+func GetAllVersionsFromGraphQL(githubToken, org, repo string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return NewClient(WithToken(githubToken)).GetAllVersionsFromGraphQL(org, repo, opts...)
+}
+
+// GetAllVersionsFromGraphQL will return a libdependency.VersionFetcherFunc that can retrieve all versions for a given
+// GitHub org/repo, like GetAllVersions but using far fewer requests through the GraphQL API.
+// The GraphQL API requires a token, and does not return release assets.
+func (c *Client) GetAllVersionsFromGraphQL(org, repo string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return func() (versionology.VersionFetcherArray, error) {
+		githubReleaseNames, err := c.getReleasesFromGraphQL(org, repo)
+		if err != nil {
+			return versionology.NewVersionFetcherArray(), err
+		}
+
+		return newReleaseVersions(githubReleaseNames, org, repo, newSourceOptions(opts)), nil
+	}
+}
+
+// getReleasesFromGraphQL will return every release of the given repo, following the cursor of each page
+// as documented by https://docs.github.com/en/graphql/guides/using-pagination-in-the-graphql-api
+func (c *Client) getReleasesFromGraphQL(org, repo string) ([]GithubReleaseNamesDTO, error) {
+	allReleases := make([]GithubReleaseNamesDTO, 0)
+
+	var cursor *string
+	for page := 1; ; page++ {
+		if c.maxPages > 0 && page > c.maxPages {
+			fmt.Printf("Stopping after %d pages of releases of %s/%s\n", c.maxPages, org, repo)
+			break
+		}
+
+		var response graphQLReleasesResponse
+		err := c.queryGraphQL(graphQLRequest{
+			Query: releasesQuery,
+			Variables: map[string]any{
+				"owner": org,
+				"name":  repo,
+				"after": cursor,
+			},
+		}, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.Data.Repository == nil {
+			return nil, fmt.Errorf("repository %s/%s not found", org, repo)
+		}
+
+		releases := response.Data.Repository.Releases
+		for _, node := range releases.Nodes {
+			allReleases = append(allReleases, GithubReleaseNamesDTO{
+				ID:          node.DatabaseID,
+				Name:        node.Name,
+				TagName:     node.TagName,
+				Prerelease:  node.IsPrerelease,
+				Draft:       node.IsDraft,
+				PublishedAt: node.PublishedAt,
+				HTMLURL:     node.URL,
+			})
+		}
+
+		if !releases.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &releases.PageInfo.EndCursor
+	}
+
+	return allReleases, nil
+}
+
+// queryGraphQL will send the query to the GraphQL endpoint and decode the response into response,
+// returning the errors of the query as an error
+func (c *Client) queryGraphQL(query graphQLRequest, response *graphQLReleasesResponse) error {
+	content, err := json.Marshal(query)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.newRequest("POST", c.graphQLURL, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to query url %s with: status code %d", c.graphQLURL, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(body, response)
+	if err != nil {
+		return err
+	}
+
+	if len(response.Errors) > 0 {
+		messages := make([]string, 0, len(response.Errors))
+		for _, graphQLErr := range response.Errors {
+			messages = append(messages, graphQLErr.Message)
+		}
+		return fmt.Errorf("failed to query url %s with: %s", c.graphQLURL, strings.Join(messages, "; "))
+	}
+
+	return nil
+}
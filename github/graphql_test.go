@@ -0,0 +1,188 @@
+package github_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/paketo-buildpacks/libdependency/github"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testGraphQL(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	type releaseNode struct {
+		DatabaseID   int64     `json:"databaseId"`
+		Name         string    `json:"name"`
+		TagName      string    `json:"tagName"`
+		IsPrerelease bool      `json:"isPrerelease"`
+		IsDraft      bool      `json:"isDraft"`
+		PublishedAt  time.Time `json:"publishedAt"`
+		URL          string    `json:"url"`
+	}
+
+	var (
+		server      *httptest.Server
+		releases    []releaseNode
+		requests    []map[string]any
+		headers     []http.Header
+		queryErrors []string
+	)
+
+	it.Before(func() {
+		releases = nil
+		requests = nil
+		headers = nil
+		queryErrors = nil
+
+		// The stand-in endpoint pages through releases 100 at a time, using the index of the next release as cursor
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /api/graphql", func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				Query     string         `json:"query"`
+				Variables map[string]any `json:"variables"`
+			}
+			Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+			requests = append(requests, request.Variables)
+			headers = append(headers, r.Header)
+
+			w.Header().Set("Content-Type", "application/json")
+			if len(queryErrors) > 0 {
+				var graphQLErrors []map[string]string
+				for _, message := range queryErrors {
+					graphQLErrors = append(graphQLErrors, map[string]string{"message": message})
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"repository": nil}, "errors": graphQLErrors})
+				return
+			}
+
+			start := 0
+			if after, ok := request.Variables["after"].(string); ok {
+				start, _ = strconv.Atoi(after)
+			}
+			end := min(start+100, len(releases))
+
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"repository": map[string]any{
+						"releases": map[string]any{
+							"nodes": releases[start:end],
+							"pageInfo": map[string]any{
+								"hasNextPage": end < len(releases),
+								"endCursor":   strconv.Itoa(end),
+							},
+						},
+					},
+				},
+			})
+		})
+
+		server = httptest.NewServer(mux)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	newClient := func(opts ...ClientOption) *Client {
+		return NewClient(append([]ClientOption{WithBaseURL(server.URL + "/api/v3"), WithHTTPClient(server.Client())}, opts...)...)
+	}
+
+	it("will return the releases newest first", func() {
+		releases = []releaseNode{
+			{Name: "1.0.0", TagName: "v1.0.0"},
+			{Name: "Release 2.0.0", TagName: "v2.0.0"},
+			{Name: "2.1.0-rc.1", TagName: "v2.1.0-rc.1", IsPrerelease: true},
+			{Name: "3.0.0", TagName: "v3.0.0", IsDraft: true},
+		}
+
+		versions, err := newClient(WithToken("some-token")).GetAllVersionsFromGraphQL("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions.GetVersionStrings()).To(Equal([]string{"2.0.0", "1.0.0"}))
+
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0]).To(Equal(map[string]any{"owner": "some-org", "name": "some-repo", "after": nil}))
+		Expect(headers[0].Get("Authorization")).To(Equal("token some-token"))
+	})
+
+	it("will include prereleases and drafts with the options", func() {
+		releases = []releaseNode{
+			{Name: "2.1.0-rc.1", TagName: "v2.1.0-rc.1", IsPrerelease: true},
+			{Name: "3.0.0", TagName: "v3.0.0", IsDraft: true},
+		}
+
+		versions, err := newClient().GetAllVersionsFromGraphQL("some-org", "some-repo", WithPrereleases(true), WithDrafts(true))()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions.GetVersionStrings()).To(Equal([]string{"3.0.0", "2.1.0-rc.1"}))
+	})
+
+	it("will expose the release metadata", func() {
+		publishedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		releases = []releaseNode{
+			{DatabaseID: 42, Name: "1.0.0", TagName: "v1.0.0", PublishedAt: publishedAt, URL: "https://github.com/some-org/some-repo/releases/tag/v1.0.0"},
+		}
+
+		versions, err := newClient().GetAllVersionsFromGraphQL("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(1))
+
+		release, ok := versions[0].(Release)
+		Expect(ok).To(BeTrue())
+		Expect(release.ID).To(Equal(int64(42)))
+		Expect(release.ReleaseDate()).To(Equal(publishedAt))
+		Expect(release.HTMLURL).To(Equal("https://github.com/some-org/some-repo/releases/tag/v1.0.0"))
+	})
+
+	it("will follow the cursor of each page", func() {
+		for i := 0; i < 250; i++ {
+			releases = append(releases, releaseNode{TagName: fmt.Sprintf("v1.0.%d", i)})
+		}
+
+		versions, err := newClient().GetAllVersionsFromGraphQL("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(250))
+		Expect(requests).To(HaveLen(3))
+		Expect(requests[1]["after"]).To(Equal("100"))
+		Expect(requests[2]["after"]).To(Equal("200"))
+	})
+
+	it("will stop after the maximum number of pages", func() {
+		for i := 0; i < 250; i++ {
+			releases = append(releases, releaseNode{TagName: fmt.Sprintf("v1.0.%d", i)})
+		}
+
+		versions, err := newClient(WithMaxPages(1)).GetAllVersionsFromGraphQL("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(100))
+		Expect(requests).To(HaveLen(1))
+	})
+
+	it("will use the GraphQL URL when configured", func() {
+		releases = []releaseNode{{TagName: "v1.0.0"}}
+
+		versions, err := NewClient(WithHTTPClient(server.Client()), WithGraphQLURL(server.URL+"/api/graphql")).
+			GetAllVersionsFromGraphQL("some-org", "some-repo")()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions.GetVersionStrings()).To(Equal([]string{"1.0.0"}))
+	})
+
+	it("will return the errors of the query", func() {
+		queryErrors = []string{"Could not resolve to a Repository with the name 'some-org/some-repo'."}
+
+		_, err := newClient().GetAllVersionsFromGraphQL("some-org", "some-repo")()
+		Expect(err).To(MatchError(ContainSubstring("/api/graphql with: Could not resolve to a Repository")))
+	})
+
+	it("will return an error for an unsuccessful response", func() {
+		_, err := NewClient(WithHTTPClient(server.Client()), WithGraphQLURL(server.URL+"/missing")).
+			GetAllVersionsFromGraphQL("some-org", "some-repo")()
+		Expect(err).To(MatchError(ContainSubstring("/missing with: status code 404")))
+	})
+}
@@ -10,5 +10,12 @@ import (
 func TestUnitFuncs(t *testing.T) {
 	suite := spec.New("github", spec.Report(report.Terminal{}))
 	suite("Github", testGithub)
+	suite("Assets", testAssets)
+	suite("RateLimit", testRateLimit)
+	suite("Client", testClient)
+	suite("Cache", testCache)
+	suite("GraphQL", testGraphQL)
+	suite("Auth", testAuth)
+	suite("Commits", testCommits)
 	suite.Run(t)
 }
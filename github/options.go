@@ -0,0 +1,45 @@
+package github
+
+import "github.com/paketo-buildpacks/libdependency/versionology"
+
+// Option configures how a GitHub source translates releases or tags into versions
+type Option func(*sourceOptions)
+
+type sourceOptions struct {
+	versionExtractor   versionology.VersionExtractor
+	includePrereleases bool
+	includeDrafts      bool
+}
+
+// WithVersionExtractor will use extractor to translate release names, release tags and tag names into versions,
+// instead of versionology.DefaultVersionExtractor
+func WithVersionExtractor(extractor versionology.VersionExtractor) Option {
+	return func(o *sourceOptions) {
+		o.versionExtractor = extractor
+	}
+}
+
+// WithPrereleases will include releases that GitHub flags as prereleases, which are excluded by default
+func WithPrereleases(include bool) Option {
+	return func(o *sourceOptions) {
+		o.includePrereleases = include
+	}
+}
+
+// WithDrafts will include draft releases, which are excluded by default.
+// Drafts are only visible to tokens with push access to the repo.
+func WithDrafts(include bool) Option {
+	return func(o *sourceOptions) {
+		o.includeDrafts = include
+	}
+}
+
+func newSourceOptions(opts []Option) sourceOptions {
+	options := sourceOptions{
+		versionExtractor: versionology.DefaultVersionExtractor,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
@@ -0,0 +1,189 @@
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a rate limited request is retried by default
+	DefaultMaxRetries = 3
+	// DefaultMaxWait is the total time spent waiting for rate limits to reset by default
+	DefaultMaxWait = 5 * time.Minute
+
+	// secondaryRateLimitBackoff is how long to wait for a secondary rate limit that does not tell us how long to wait,
+	// as recommended by https://docs.github.com/en/rest/using-the-rest-api/rate-limits-for-the-rest-api
+	secondaryRateLimitBackoff = time.Minute
+)
+
+// RateLimitError is returned when the GitHub API keeps rate limiting requests after the retry budget is used up
+type RateLimitError struct {
+	// Resource is the rate limit that was hit (e.g. "core"), or "secondary" for secondary rate limits
+	Resource string
+	// Limit is the maximum number of requests per hour for Resource, or 0 if unknown
+	Limit int
+	// Reset is when the rate limit resets, or the zero time if unknown
+	Reset time.Time
+	// Retries is the number of retries made before giving up
+	Retries int
+}
+
+func (e RateLimitError) Error() string {
+	details := make([]string, 0)
+	if e.Limit > 0 {
+		details = append(details, fmt.Sprintf("limit %d", e.Limit))
+	}
+	if !e.Reset.IsZero() {
+		details = append(details, fmt.Sprintf("resets at %s", e.Reset.UTC().Format(time.RFC3339)))
+	}
+
+	description := ""
+	if len(details) > 0 {
+		description = fmt.Sprintf(" (%s)", strings.Join(details, ", "))
+	}
+
+	return fmt.Sprintf("GitHub API rate limit '%s' exceeded%s, gave up after %d retries", e.Resource, description, e.Retries)
+}
+
+// RateLimitTransport is an http.RoundTripper that waits for, and retries, requests that the GitHub API rejects because
+// of primary or secondary rate limits, using X-RateLimit-Remaining, X-RateLimit-Reset and Retry-After.
+// Once MaxRetries or MaxWait is used up, it returns a RateLimitError.
+type RateLimitTransport struct {
+	// Transport makes the requests, http.DefaultTransport when nil
+	Transport http.RoundTripper
+	// MaxRetries is the maximum number of retries per request
+	MaxRetries int
+	// MaxWait is the maximum total time to wait per request
+	MaxWait time.Duration
+	// Sleep waits for the given duration, time.Sleep when nil
+	Sleep func(time.Duration)
+	// Now returns the current time, time.Now when nil
+	Now func() time.Time
+}
+
+// NewRateLimitTransport will return a RateLimitTransport wrapping transport with DefaultMaxRetries and DefaultMaxWait
+func NewRateLimitTransport(transport http.RoundTripper) *RateLimitTransport {
+	return &RateLimitTransport{
+		Transport:  transport,
+		MaxRetries: DefaultMaxRetries,
+		MaxWait:    DefaultMaxWait,
+	}
+}
+
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	sleep := t.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	now := t.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	var waited time.Duration
+	for retries := 0; ; retries++ {
+		// A RoundTripper must not modify the request, so retries are made with a clone that has a fresh body
+		attempt := req
+		if retries > 0 {
+			attempt = req.Clone(req.Context())
+			if req.Body != nil && req.Body != http.NoBody {
+				if req.GetBody == nil {
+					return nil, fmt.Errorf("unable to retry request with a body that cannot be replayed")
+				}
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attempt.Body = body
+			}
+		}
+
+		res, err := transport.RoundTrip(attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		limited, err := isRateLimited(res)
+		if err != nil {
+			return nil, err
+		}
+		if !limited {
+			return res, nil
+		}
+
+		wait, rateLimitErr := rateLimitWait(res, now(), retries)
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+
+		if retries >= t.MaxRetries || waited+wait > t.MaxWait {
+			rateLimitErr.Retries = retries
+			return nil, rateLimitErr
+		}
+
+		sleep(wait)
+		waited += wait
+	}
+}
+
+// isRateLimited will tell whether the response was rejected because of a rate limit.
+// GitHub answers 403 for both rate limits and missing permissions, so 403 responses
+// without rate limit headers are checked for the secondary rate limit message.
+func isRateLimited(res *http.Response) (bool, error) {
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true, nil
+	case http.StatusForbidden:
+		if res.Header.Get("Retry-After") != "" || res.Header.Get("X-RateLimit-Remaining") == "0" {
+			return true, nil
+		}
+
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			return false, err
+		}
+		_ = res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(body))
+
+		return strings.Contains(strings.ToLower(string(body)), "rate limit"), nil
+	default:
+		return false, nil
+	}
+}
+
+// rateLimitWait will return how long to wait before retrying a rate limited response,
+// and the RateLimitError describing the limit in case the retry budget does not allow waiting
+func rateLimitWait(res *http.Response, now time.Time, retries int) (time.Duration, RateLimitError) {
+	rateLimitErr := RateLimitError{Resource: "secondary"}
+	if res.Header.Get("X-RateLimit-Remaining") == "0" {
+		rateLimitErr.Resource = res.Header.Get("X-RateLimit-Resource")
+		if rateLimitErr.Resource == "" {
+			rateLimitErr.Resource = "core"
+		}
+	}
+	if limit, err := strconv.Atoi(res.Header.Get("X-RateLimit-Limit")); err == nil {
+		rateLimitErr.Limit = limit
+	}
+	if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rateLimitErr.Reset = time.Unix(reset, 0)
+	}
+
+	if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, rateLimitErr
+	}
+
+	if rateLimitErr.Resource != "secondary" && !rateLimitErr.Reset.IsZero() {
+		// The reset time has a resolution of seconds, so wait an extra second to be sure it has passed
+		return max(rateLimitErr.Reset.Sub(now), 0) + time.Second, rateLimitErr
+	}
+
+	return secondaryRateLimitBackoff << retries, rateLimitErr
+}
@@ -0,0 +1,172 @@
+package github_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/paketo-buildpacks/libdependency/github"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testRateLimit(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	var (
+		server    *httptest.Server
+		responses []func(w http.ResponseWriter)
+		requests  int
+		bodies    []string
+		waits     []time.Duration
+		now       time.Time
+		client    *http.Client
+	)
+
+	it.Before(func() {
+		requests = 0
+		bodies = nil
+		waits = nil
+		now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+
+			response := responses[min(requests, len(responses)-1)]
+			requests++
+			response(w)
+		}))
+
+		transport := NewRateLimitTransport(nil)
+		transport.Sleep = func(d time.Duration) {
+			waits = append(waits, d)
+		}
+		transport.Now = func() time.Time {
+			return now
+		}
+		client = &http.Client{Transport: transport}
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	ok := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, "[]")
+	}
+
+	primaryLimit := func(w http.ResponseWriter) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Resource", "core")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(now.Add(30*time.Second).Unix()))
+		w.WriteHeader(http.StatusForbidden)
+	}
+
+	it("will not retry successful requests", func() {
+		responses = []func(w http.ResponseWriter){ok}
+
+		res, err := client.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(requests).To(Equal(1))
+		Expect(waits).To(BeEmpty())
+	})
+
+	it("will not retry a 403 that is not a rate limit", func() {
+		responses = []func(w http.ResponseWriter){func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprint(w, `{"message": "Resource not accessible by integration"}`)
+		}}
+
+		res, err := client.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusForbidden))
+		Expect(requests).To(Equal(1))
+	})
+
+	it("will wait until the primary rate limit resets", func() {
+		responses = []func(w http.ResponseWriter){primaryLimit, ok}
+
+		res, err := client.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(requests).To(Equal(2))
+		Expect(waits).To(Equal([]time.Duration{31 * time.Second}))
+	})
+
+	it("will retry a request with a body without modifying the request", func() {
+		responses = []func(w http.ResponseWriter){primaryLimit, ok}
+
+		req, err := http.NewRequest("POST", server.URL, strings.NewReader(`{"query": "{}"}`))
+		Expect(err).NotTo(HaveOccurred())
+		originalBody := req.Body
+
+		res, err := client.Transport.RoundTrip(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(bodies).To(Equal([]string{`{"query": "{}"}`, `{"query": "{}"}`}))
+		Expect(req.Body).To(BeIdenticalTo(originalBody))
+	})
+
+	it("will wait as long as Retry-After says", func() {
+		responses = []func(w http.ResponseWriter){func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, ok}
+
+		res, err := client.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(waits).To(Equal([]time.Duration{7 * time.Second}))
+	})
+
+	it("will back off exponentially for secondary rate limits without headers", func() {
+		responses = []func(w http.ResponseWriter){func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprint(w, `{"message": "You have exceeded a secondary rate limit."}`)
+		}, func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}, ok}
+
+		res, err := client.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(waits).To(Equal([]time.Duration{time.Minute, 2 * time.Minute}))
+	})
+
+	it("will return a RateLimitError when the retries run out", func() {
+		responses = []func(w http.ResponseWriter){primaryLimit}
+
+		_, err := client.Get(server.URL)
+		Expect(err).To(MatchError(ContainSubstring("GitHub API rate limit 'core' exceeded (limit 60, resets at 2025-01-01T12:00:30Z), gave up after 3 retries")))
+		Expect(requests).To(Equal(4))
+
+		var rateLimitErr RateLimitError
+		Expect(errors.As(err, &rateLimitErr)).To(BeTrue())
+		Expect(rateLimitErr.Resource).To(Equal("core"))
+		Expect(rateLimitErr.Limit).To(Equal(60))
+	})
+
+	it("will return a RateLimitError without waiting beyond the maximum wait", func() {
+		responses = []func(w http.ResponseWriter){func(w http.ResponseWriter) {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Resource", "search")
+			w.Header().Set("X-RateLimit-Reset", fmt.Sprint(now.Add(time.Hour).Unix()))
+			w.WriteHeader(http.StatusForbidden)
+		}}
+
+		_, err := client.Get(server.URL)
+		Expect(err).To(MatchError(ContainSubstring("GitHub API rate limit 'search' exceeded (resets at 2025-01-01T13:00:00Z), gave up after 0 retries")))
+		Expect(requests).To(Equal(1))
+		Expect(waits).To(BeEmpty())
+	})
+}
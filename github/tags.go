@@ -0,0 +1,38 @@
+package github
+
+import (
+	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+)
+
+type GithubTagDTO struct {
+	Name   string          `json:"name"`
+	Commit GithubCommitDTO `json:"commit"`
+}
+
+type GithubCommitDTO struct {
+	SHA string `json:"sha"`
+}
+
+// SanitizeGithubTagName will return the semver version of the tag name
+func SanitizeGithubTagName(tag GithubTagDTO) (*semver.Version, error) {
+	return versionology.DefaultVersionExtractor(tag.Name)
+}
+
+// Tag is a versionology.VersionFetcher for a GitHub tag
+type Tag struct {
+	GithubTagDTO
+	version *semver.Version
+}
+
+// NewTag will return a Tag for the given tag and its sanitized version
+func NewTag(tag GithubTagDTO, version *semver.Version) Tag {
+	return Tag{
+		GithubTagDTO: tag,
+		version:      version,
+	}
+}
+
+func (t Tag) Version() *semver.Version {
+	return t.version
+}
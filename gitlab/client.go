@@ -0,0 +1,171 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/paketo-buildpacks/libdependency/internal/rest"
+)
+
+// DefaultBaseURL is the base URL of the REST API of gitlab.com
+const DefaultBaseURL = "https://gitlab.com/api/v4"
+
+// Authenticator adds credentials to requests made to the GitLab API
+type Authenticator = rest.Authenticator
+
+// AuthenticatorFunc is an Authenticator implemented by a function
+type AuthenticatorFunc = rest.AuthenticatorFunc
+
+// PrivateTokenAuth will return an Authenticator that sends a personal, project or group access token
+// in the PRIVATE-TOKEN header, or that sends no credentials when token is empty
+func PrivateTokenAuth(token string) Authenticator {
+	return headerAuth("PRIVATE-TOKEN", token)
+}
+
+// JobTokenAuth will return an Authenticator that sends the token of a CI/CD job, i.e. $CI_JOB_TOKEN,
+// in the JOB-TOKEN header, or that sends no credentials when token is empty
+func JobTokenAuth(token string) Authenticator {
+	return headerAuth("JOB-TOKEN", token)
+}
+
+func headerAuth(header, token string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		if token != "" {
+			req.Header.Set(header, token)
+		}
+		return nil
+	})
+}
+
+// Client queries the REST API of gitlab.com or of a self-managed GitLab
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	auth       Authenticator
+	maxPages   int
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithBaseURL will query the API at baseURL instead of DefaultBaseURL,
+// e.g. https://gitlab.example.com/api/v4 for a self-managed GitLab
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient will make requests with httpClient instead of a default http.Client
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserAgent will send userAgent as the User-Agent of every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithAuth will use auth to add credentials to every request
+func WithAuth(auth Authenticator) ClientOption {
+	return func(c *Client) {
+		c.auth = auth
+	}
+}
+
+// WithPrivateToken will send token in the PRIVATE-TOKEN header of every request
+func WithPrivateToken(token string) ClientOption {
+	return WithAuth(PrivateTokenAuth(token))
+}
+
+// WithJobToken will send the token of a CI/CD job in the JOB-TOKEN header of every request
+func WithJobToken(token string) ClientOption {
+	return WithAuth(JobTokenAuth(token))
+}
+
+// WithMaxPages will stop listing after maxPages pages, which limits the number of requests for very large projects
+// at the cost of only returning the items on those pages. By default, every page is requested.
+func WithMaxPages(maxPages int) ClientOption {
+	return func(c *Client) {
+		c.maxPages = maxPages
+	}
+}
+
+// NewClient will return a Client for DefaultBaseURL without credentials, unless configured otherwise by opts
+func NewClient(opts ...ClientOption) *Client {
+	client := &Client{
+		baseURL:    DefaultBaseURL,
+		httpClient: &http.Client{},
+		userAgent:  "paketo-buildpacks/libdependency",
+		auth:       PrivateTokenAuth(""),
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client
+}
+
+// projectPath will return the API path of the project, which is either its numeric ID or its URL-encoded full path
+// as documented by https://docs.gitlab.com/api/rest/#namespaced-paths
+func projectPath(project string) string {
+	return fmt.Sprintf("projects/%s", url.PathEscape(project))
+}
+
+// url will return the URL of the given API path and query
+func (c *Client) url(path, query string) string {
+	urlString := fmt.Sprintf("%s/%s", c.baseURL, strings.TrimPrefix(path, "/"))
+	if query != "" {
+		urlString = fmt.Sprintf("%s?%s", urlString, query)
+	}
+	return urlString
+}
+
+// getJSON will make an authenticated GET request for the given URL, decode the body of a successful response into v
+// and return the headers of the response
+func (c *Client) getJSON(urlString string, v any) (http.Header, error) {
+	req, err := http.NewRequest("GET", urlString, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	err = c.auth.Authenticate(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to authenticate request to %s: %w", urlString, err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query url %s with: status code %d", urlString, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(body, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Header, nil
+}
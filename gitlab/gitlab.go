@@ -0,0 +1,181 @@
+package gitlab
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/libdependency/internal/rest"
+	"github.com/paketo-buildpacks/libdependency/retrieve"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+)
+
+type GitlabReleaseDTO struct {
+	Name            string                `json:"name"`
+	TagName         string                `json:"tag_name"`
+	UpcomingRelease bool                  `json:"upcoming_release"`
+	ReleasedAt      time.Time             `json:"released_at"`
+	Links           GitlabReleaseLinksDTO `json:"_links"`
+}
+
+type GitlabReleaseLinksDTO struct {
+	Self string `json:"self"`
+}
+
+type GitlabTagDTO struct {
+	Name   string          `json:"name"`
+	Commit GitlabCommitDTO `json:"commit"`
+}
+
+type GitlabCommitDTO struct {
+	ID            string    `json:"id"`
+	CommittedDate time.Time `json:"committed_date"`
+}
+
+// Release is a versionology.VersionFetcher for a GitLab release, which exposes the release metadata
+// and also implements versionology.ReleaseDateFetcher using the release's `released_at`
+type Release struct {
+	GitlabReleaseDTO
+	version *semver.Version
+}
+
+// NewRelease will return a Release for the given release and its sanitized version
+func NewRelease(release GitlabReleaseDTO, version *semver.Version) Release {
+	return Release{
+		GitlabReleaseDTO: release,
+		version:          version,
+	}
+}
+
+func (r Release) Version() *semver.Version {
+	return r.version
+}
+
+func (r Release) ReleaseDate() time.Time {
+	return r.ReleasedAt
+}
+
+// Tag is a versionology.VersionFetcher for a GitLab tag
+type Tag struct {
+	GitlabTagDTO
+	version *semver.Version
+}
+
+// NewTag will return a Tag for the given tag and its sanitized version
+func NewTag(tag GitlabTagDTO, version *semver.Version) Tag {
+	return Tag{
+		GitlabTagDTO: tag,
+		version:      version,
+	}
+}
+
+func (t Tag) Version() *semver.Version {
+	return t.version
+}
+
+// GetAllVersions will return a libdependency.VersionFetcherFunc that can retrieve all versions for a given
+// gitlab.com project, which is either its numeric ID or its full path such as `group/subgroup/project`.
+// The token is sent as a PRIVATE-TOKEN when it is not empty.
+func GetAllVersions(token, project string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return NewClient(WithPrivateToken(token)).GetAllVersions(project, opts...)
+}
+
+// GetAllVersionsFromTags will return a libdependency.VersionFetcherFunc that can retrieve all versions for a given
+// gitlab.com project from its tags, for projects that do not publish releases.
+func GetAllVersionsFromTags(token, project string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return NewClient(WithPrivateToken(token)).GetAllVersionsFromTags(project, opts...)
+}
+
+// GetAllVersionsFromReleasesOrTags will return a libdependency.VersionFetcherFunc that can retrieve all versions for
+// a given gitlab.com project from its releases, falling back to its tags when there are no releases with a valid version.
+func GetAllVersionsFromReleasesOrTags(token, project string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return NewClient(WithPrivateToken(token)).GetAllVersionsFromReleasesOrTags(project, opts...)
+}
+
+// GetAllVersions will return a libdependency.VersionFetcherFunc that can retrieve all versions for a given project
+func (c *Client) GetAllVersions(project string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return func() (versionology.VersionFetcherArray, error) {
+		return c.getReleases(project, newSourceOptions(opts))
+	}
+}
+
+// GetAllVersionsFromTags will return a libdependency.VersionFetcherFunc that can retrieve all versions for a given
+// project from its tags, for projects that do not publish releases.
+func (c *Client) GetAllVersionsFromTags(project string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return func() (versionology.VersionFetcherArray, error) {
+		return c.getTags(project, newSourceOptions(opts))
+	}
+}
+
+// GetAllVersionsFromReleasesOrTags will return a libdependency.VersionFetcherFunc that can retrieve all versions for
+// a given project from its releases, falling back to its tags when there are no releases with a valid version.
+func (c *Client) GetAllVersionsFromReleasesOrTags(project string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return func() (versionology.VersionFetcherArray, error) {
+		options := newSourceOptions(opts)
+
+		versions, err := c.getReleases(project, options)
+		if err != nil || len(versions) > 0 {
+			return versions, err
+		}
+
+		fmt.Printf("Found no releases of %s, using tags instead\n", project)
+		return c.getTags(project, options)
+	}
+}
+
+// getReleases will return all semver-compatible versions from the releases of the given project,
+// excluding upcoming releases unless the options include them, as documented by https://docs.gitlab.com/api/releases/
+func (c *Client) getReleases(project string, options sourceOptions) (versionology.VersionFetcherArray, error) {
+	gitlabReleases, err := getAllPages[GitlabReleaseDTO](c, fmt.Sprintf("%s/releases", projectPath(project)), "per_page=100")
+	if err != nil {
+		return versionology.NewVersionFetcherArray(), err
+	}
+
+	allReleases := make([]Release, 0)
+	for _, release := range gitlabReleases {
+		if release.UpcomingRelease && !options.includeUpcomingReleases {
+			fmt.Printf("Skipping upcoming release '%s' with tag '%s' of %s\n", release.Name, release.TagName, project)
+			continue
+		}
+
+		version, err := options.versionExtractor(release.Name)
+		if err != nil {
+			version, err = options.versionExtractor(release.TagName)
+		}
+
+		if err != nil {
+			fmt.Printf("Skipping release '%s' with tag '%s' of %s: %s\n", release.Name, release.TagName, project, err)
+		} else {
+			allReleases = append(allReleases, NewRelease(release, version))
+		}
+	}
+
+	return versionology.SortedNewestFirst(allReleases), nil
+}
+
+// getTags will return all semver-compatible versions from the tags of the given project,
+// using keyset pagination as documented by https://docs.gitlab.com/api/tags/
+func (c *Client) getTags(project string, options sourceOptions) (versionology.VersionFetcherArray, error) {
+	gitlabTags, err := getAllPages[GitlabTagDTO](c, fmt.Sprintf("%s/repository/tags", projectPath(project)),
+		"pagination=keyset&order_by=name&sort=desc&per_page=100")
+	if err != nil {
+		return versionology.NewVersionFetcherArray(), err
+	}
+
+	allTags := make([]Tag, 0)
+	for _, tag := range gitlabTags {
+		if version, err := options.versionExtractor(tag.Name); err != nil {
+			fmt.Printf("Skipping tag '%s' of %s: %s\n", tag.Name, project, err)
+		} else {
+			allTags = append(allTags, NewTag(tag, version))
+		}
+	}
+
+	return versionology.SortedNewestFirst(allTags), nil
+}
+
+// getAllPages will return the items of every page of the given GitLab API path, following the "next" links of the
+// Link header, which GitLab sends for both offset and keyset pagination as documented by https://docs.gitlab.com/api/rest/#pagination
+func getAllPages[T any](c *Client, path, query string) ([]T, error) {
+	return rest.GetAllPages[T](c.getJSON, c.url(path, query), c.maxPages, path)
+}
@@ -0,0 +1,198 @@
+package gitlab_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/paketo-buildpacks/libdependency/gitlab"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testGitlab(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	var (
+		server   *httptest.Server
+		releases []GitlabReleaseDTO
+		tags     []GitlabTagDTO
+		requests []*http.Request
+	)
+
+	it.Before(func() {
+		releases = nil
+		tags = nil
+		requests = nil
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /api/v4/projects/{project}/releases", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			if r.PathValue("project") != "some-group/some-project" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			// offset pagination
+			page, err := strconv.Atoi(r.URL.Query().Get("page"))
+			if err != nil {
+				page = 1
+			}
+			start := min((page-1)*100, len(releases))
+			end := min(start+100, len(releases))
+			if end < len(releases) {
+				w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=%d&per_page=100>; rel="next"`, r.Host, r.URL.EscapedPath(), page+1))
+			}
+			_ = json.NewEncoder(w).Encode(releases[start:end])
+		})
+		mux.HandleFunc("GET /api/v4/projects/{project}/repository/tags", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+
+			// keyset pagination, where the cursor is the index of the first tag of the page
+			start := 0
+			if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+				start, _ = strconv.Atoi(cursor)
+			}
+			end := min(start+100, len(tags))
+			if end < len(tags) {
+				w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?pagination=keyset&per_page=100&cursor=%d>; rel="next"`, r.Host, r.URL.EscapedPath(), end))
+			}
+			_ = json.NewEncoder(w).Encode(tags[start:end])
+		})
+
+		server = httptest.NewServer(mux)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	newClient := func(opts ...ClientOption) *Client {
+		return NewClient(append([]ClientOption{WithBaseURL(server.URL + "/api/v4/"), WithHTTPClient(server.Client())}, opts...)...)
+	}
+
+	context("GetAllVersions", func() {
+		it("will return the releases newest first", func() {
+			releasedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+			releases = []GitlabReleaseDTO{
+				{Name: "1.0.0", TagName: "v1.0.0", ReleasedAt: releasedAt},
+				{Name: "Release 2.0.0", TagName: "v2.0.0"},
+				{Name: "3.0.0", TagName: "v3.0.0", UpcomingRelease: true},
+				{Name: "Not a version", TagName: "not-a-version"},
+			}
+
+			versions, err := newClient().GetAllVersions("some-group/some-project")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"2.0.0", "1.0.0"}))
+
+			release, ok := versions[1].(Release)
+			Expect(ok).To(BeTrue())
+			Expect(release.ReleaseDate()).To(Equal(releasedAt))
+
+			Expect(requests[0].URL.EscapedPath()).To(Equal("/api/v4/projects/some-group%2Fsome-project/releases"))
+		})
+
+		it("will include upcoming releases with the option", func() {
+			releases = []GitlabReleaseDTO{{Name: "3.0.0", TagName: "v3.0.0", UpcomingRelease: true}}
+
+			versions, err := newClient().GetAllVersions("some-group/some-project", WithUpcomingReleases(true))()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"3.0.0"}))
+		})
+
+		it("will use the version extractor", func() {
+			releases = []GitlabReleaseDTO{{Name: "Release 1_2_3", TagName: "release-1_2_3"}}
+
+			extractor, err := versionology.NewRegexVersionExtractor(`^release-(?P<version>.+)$`)
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err := newClient().GetAllVersions("some-group/some-project", WithVersionExtractor(versionology.UnderscoresToDots(extractor)))()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"1.2.3"}))
+		})
+
+		it("will follow the Link header", func() {
+			for i := 0; i < 150; i++ {
+				releases = append(releases, GitlabReleaseDTO{TagName: fmt.Sprintf("v1.0.%d", i)})
+			}
+
+			versions, err := newClient().GetAllVersions("some-group/some-project")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(150))
+			Expect(requests).To(HaveLen(2))
+		})
+
+		it("will stop after the maximum number of pages", func() {
+			for i := 0; i < 150; i++ {
+				releases = append(releases, GitlabReleaseDTO{TagName: fmt.Sprintf("v1.0.%d", i)})
+			}
+
+			versions, err := newClient(WithMaxPages(1)).GetAllVersions("some-group/some-project")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(100))
+		})
+
+		it("will send a private token", func() {
+			_, err := newClient(WithPrivateToken("some-token"), WithUserAgent("some-agent")).GetAllVersions("some-group/some-project")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests[0].Header.Get("PRIVATE-TOKEN")).To(Equal("some-token"))
+			Expect(requests[0].Header.Get("JOB-TOKEN")).To(BeEmpty())
+			Expect(requests[0].Header.Get("User-Agent")).To(Equal("some-agent"))
+		})
+
+		it("will send a job token", func() {
+			_, err := newClient(WithJobToken("some-job-token")).GetAllVersions("some-group/some-project")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests[0].Header.Get("JOB-TOKEN")).To(Equal("some-job-token"))
+			Expect(requests[0].Header.Get("PRIVATE-TOKEN")).To(BeEmpty())
+		})
+
+		it("will return an error for an unsuccessful response", func() {
+			_, err := newClient().GetAllVersions("some-group/missing-project")()
+			Expect(err).To(MatchError(ContainSubstring("/api/v4/projects/some-group%2Fmissing-project/releases?per_page=100 with: status code 404")))
+		})
+	})
+
+	context("GetAllVersionsFromTags", func() {
+		it("will return the tags of every page newest first", func() {
+			for i := 0; i < 150; i++ {
+				tags = append(tags, GitlabTagDTO{Name: fmt.Sprintf("v1.0.%d", i)})
+			}
+			tags = append(tags, GitlabTagDTO{Name: "not-a-version"})
+
+			versions, err := newClient().GetAllVersionsFromTags("some-group/some-project")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions).To(HaveLen(150))
+			Expect(versions[0].Version().String()).To(Equal("1.0.149"))
+
+			Expect(requests).To(HaveLen(2))
+			Expect(requests[0].URL.Query().Get("pagination")).To(Equal("keyset"))
+			Expect(requests[1].URL.Query().Get("cursor")).To(Equal("100"))
+		})
+	})
+
+	context("GetAllVersionsFromReleasesOrTags", func() {
+		it("will use the releases when there are any", func() {
+			releases = []GitlabReleaseDTO{{TagName: "v2.0.0"}}
+			tags = []GitlabTagDTO{{Name: "v1.0.0"}}
+
+			versions, err := newClient().GetAllVersionsFromReleasesOrTags("some-group/some-project")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"2.0.0"}))
+		})
+
+		it("will use the tags when there are no releases", func() {
+			tags = []GitlabTagDTO{{Name: "v1.0.0"}}
+
+			versions, err := newClient().GetAllVersionsFromReleasesOrTags("some-group/some-project")()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"1.0.0"}))
+		})
+	})
+}
@@ -0,0 +1,14 @@
+package gitlab_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitFuncs(t *testing.T) {
+	suite := spec.New("gitlab", spec.Report(report.Terminal{}))
+	suite("Gitlab", testGitlab)
+	suite.Run(t)
+}
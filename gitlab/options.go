@@ -0,0 +1,38 @@
+package gitlab
+
+import (
+	"github.com/paketo-buildpacks/libdependency/versionology"
+)
+
+// Option configures how a GitLab source translates releases or tags into versions
+type Option func(*sourceOptions)
+
+type sourceOptions struct {
+	versionExtractor        versionology.VersionExtractor
+	includeUpcomingReleases bool
+}
+
+// WithVersionExtractor will use extractor to translate release names, release tags and tag names into versions,
+// instead of versionology.DefaultVersionExtractor, e.g. one built with versionology.NewRegexVersionExtractor
+func WithVersionExtractor(extractor versionology.VersionExtractor) Option {
+	return func(o *sourceOptions) {
+		o.versionExtractor = extractor
+	}
+}
+
+// WithUpcomingReleases will include releases whose release date is in the future, which are excluded by default
+func WithUpcomingReleases(include bool) Option {
+	return func(o *sourceOptions) {
+		o.includeUpcomingReleases = include
+	}
+}
+
+func newSourceOptions(opts []Option) sourceOptions {
+	options := sourceOptions{
+		versionExtractor: versionology.DefaultVersionExtractor,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
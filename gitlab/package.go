@@ -0,0 +1,3 @@
+// Package gitlab retrieves the versions of projects hosted on gitlab.com or on a self-managed GitLab,
+// from their releases or tags, mirroring the github package.
+package gitlab
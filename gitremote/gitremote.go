@@ -0,0 +1,170 @@
+package gitremote
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/paketo-buildpacks/libdependency/retrieve"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+)
+
+// peeledSuffix is appended to the name of a tag to name the commit of an annotated tag in the advertised references
+const peeledSuffix = "^{}"
+
+// Tag is a versionology.VersionFetcher for a git tag
+type Tag struct {
+	// Name is the short name of the tag, e.g. v1.2.3
+	Name string
+	// Hash is the object the tag points to, which is the tag object for an annotated tag
+	Hash string
+	// Commit is the commit the tag points to, after peeling annotated tags
+	Commit  string
+	version *semver.Version
+}
+
+// NewTag will return a Tag for the given tag and its sanitized version
+func NewTag(name, hash, commit string, version *semver.Version) Tag {
+	return Tag{
+		Name:    name,
+		Hash:    hash,
+		Commit:  commit,
+		version: version,
+	}
+}
+
+func (t Tag) Version() *semver.Version {
+	return t.version
+}
+
+// GetAllVersionsFromTags will return a libdependency.VersionFetcherFunc that can retrieve all versions from the tags
+// of a git remote, which is either a URL, such as https://git.example.com/project.git, or the path of a local
+// repository. Bare and non-bare local repositories are supported.
+func GetAllVersionsFromTags(remote string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return func() (versionology.VersionFetcherArray, error) {
+		options := newSourceOptions(opts)
+
+		var (
+			refs []*plumbing.Reference
+			err  error
+		)
+		if isLocalPath(remote) {
+			refs, err = listLocalTags(strings.TrimPrefix(remote, "file://"))
+		} else {
+			refs, err = listRemoteTags(remote, options)
+		}
+		if err != nil {
+			return versionology.NewVersionFetcherArray(), fmt.Errorf("unable to list tags of %s: %w", remote, err)
+		}
+
+		return newTagVersions(remote, refs, options), nil
+	}
+}
+
+// isLocalPath will tell whether the remote is a path rather than a URL, following the rules of git itself
+func isLocalPath(remote string) bool {
+	if strings.HasPrefix(remote, "file://") {
+		return true
+	}
+	if strings.Contains(remote, "://") {
+		return false
+	}
+
+	// scp-like syntax, e.g. git@example.com:project.git, has a colon before the first slash
+	colon := strings.Index(remote, ":")
+	slash := strings.Index(remote, "/")
+	return colon < 0 || (slash >= 0 && slash < colon)
+}
+
+// listRemoteTags will return the tags advertised by the remote, including the peeled commits of annotated tags
+func listRemoteTags(remote string, options sourceOptions) ([]*plumbing.Reference, error) {
+	gitRemote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{remote},
+	})
+
+	return gitRemote.List(&git.ListOptions{
+		Auth:          options.auth,
+		PeelingOption: git.AppendPeeled,
+	})
+}
+
+// listLocalTags will return the tags of the local repository, adding the peeled commits of annotated tags
+// like a remote advertises them, because the local transport does not
+func listLocalTags(path string) ([]*plumbing.Reference, error) {
+	repository, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := repository.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []*plumbing.Reference
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		refs = append(refs, ref)
+
+		hash := ref.Hash()
+		peeled := false
+		for {
+			tag, err := repository.TagObject(hash)
+			if errors.Is(err, plumbing.ErrObjectNotFound) {
+				break
+			} else if err != nil {
+				return err
+			}
+
+			hash = tag.Target
+			peeled = true
+		}
+
+		if peeled {
+			refs = append(refs, plumbing.NewHashReference(ref.Name()+peeledSuffix, hash))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+// newTagVersions will return the semver-compatible versions of the tag references, newest first
+func newTagVersions(remote string, refs []*plumbing.Reference, options sourceOptions) versionology.VersionFetcherArray {
+	peeled := make(map[plumbing.ReferenceName]plumbing.Hash)
+	for _, ref := range refs {
+		if name, ok := strings.CutSuffix(ref.Name().String(), peeledSuffix); ok {
+			peeled[plumbing.ReferenceName(name)] = ref.Hash()
+		}
+	}
+
+	allTags := make([]Tag, 0)
+	for _, ref := range refs {
+		if !ref.Name().IsTag() || strings.HasSuffix(ref.Name().String(), peeledSuffix) {
+			continue
+		}
+
+		name := ref.Name().Short()
+		commit, ok := peeled[ref.Name()]
+		if !ok {
+			commit = ref.Hash()
+		}
+
+		if version, err := options.versionExtractor(name); err != nil {
+			fmt.Printf("Skipping tag '%s' of %s: %s\n", name, remote, err)
+		} else {
+			allTags = append(allTags, NewTag(name, ref.Hash().String(), commit.String(), version))
+		}
+	}
+
+	return versionology.SortedNewestFirst(allTags)
+}
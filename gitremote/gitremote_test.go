@@ -0,0 +1,163 @@
+package gitremote_test
+
+import (
+	"net/http/cgi"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/paketo-buildpacks/libdependency/gitremote"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testGitremote(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	var (
+		workDir      string
+		bareDir      string
+		commits      []plumbing.Hash
+		annotatedTag plumbing.Hash
+	)
+
+	it.Before(func() {
+		workDir = filepath.Join(t.TempDir(), "work")
+		repository, err := git.PlainInit(workDir, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		worktree, err := repository.Worktree()
+		Expect(err).NotTo(HaveOccurred())
+
+		signature := &object.Signature{Name: "Some Name", Email: "some@example.com", When: time.Now()}
+
+		commits = nil
+		for _, content := range []string{"one", "two", "three"} {
+			Expect(os.WriteFile(filepath.Join(workDir, "file"), []byte(content), 0600)).To(Succeed())
+			_, err = worktree.Add("file")
+			Expect(err).NotTo(HaveOccurred())
+
+			commit, err := worktree.Commit(content, &git.CommitOptions{Author: signature})
+			Expect(err).NotTo(HaveOccurred())
+			commits = append(commits, commit)
+		}
+
+		_, err = repository.CreateTag("v1.0.0", commits[0], nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		annotated, err := repository.CreateTag("v1.1.0", commits[1], &git.CreateTagOptions{Tagger: signature, Message: "Release 1.1.0"})
+		Expect(err).NotTo(HaveOccurred())
+		annotatedTag = annotated.Hash()
+
+		_, err = repository.CreateTag("release-2_0_0", commits[2], &git.CreateTagOptions{Tagger: signature, Message: "Release 2.0.0"})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = repository.CreateTag("not-a-version", commits[2], nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		bareDir = filepath.Join(t.TempDir(), "project.git")
+		_, err = git.PlainClone(bareDir, true, &git.CloneOptions{URL: workDir, Tags: git.AllTags})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	tagsOf := func(versions versionology.VersionFetcherArray) []Tag {
+		var tags []Tag
+		for _, version := range versions {
+			tag, ok := version.(Tag)
+			Expect(ok).To(BeTrue())
+			tags = append(tags, tag)
+		}
+		return tags
+	}
+
+	itListsTags := func(remote func() string) {
+		it("will return the tags newest first, with annotated tags peeled", func() {
+			versions, err := GetAllVersionsFromTags(remote())()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"1.1.0", "1.0.0"}))
+
+			tags := tagsOf(versions)
+			Expect(tags[0].Name).To(Equal("v1.1.0"))
+			Expect(tags[0].Hash).To(Equal(annotatedTag.String()))
+			Expect(tags[0].Commit).To(Equal(commits[1].String()))
+			Expect(tags[1].Name).To(Equal("v1.0.0"))
+			Expect(tags[1].Hash).To(Equal(commits[0].String()))
+			Expect(tags[1].Commit).To(Equal(commits[0].String()))
+		})
+
+		it("will use the version extractor", func() {
+			extractor, err := versionology.NewRegexVersionExtractor(`^release-(?P<version>.+)$`)
+			Expect(err).NotTo(HaveOccurred())
+
+			versions, err := GetAllVersionsFromTags(remote(), WithVersionExtractor(versionology.UnderscoresToDots(extractor)))()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"2.0.0"}))
+			Expect(tagsOf(versions)[0].Commit).To(Equal(commits[2].String()))
+		})
+	}
+
+	context("with a local bare repository", func() {
+		itListsTags(func() string { return bareDir })
+	})
+
+	context("with a local repository", func() {
+		itListsTags(func() string { return workDir })
+	})
+
+	context("with a file URL", func() {
+		itListsTags(func() string { return "file://" + bareDir })
+	})
+
+	context("with a smart HTTP remote", func() {
+		var server *httptest.Server
+
+		it.Before(func() {
+			gitPath, err := exec.LookPath("git")
+			if err != nil {
+				t.Skip("git is required to serve the smart HTTP protocol")
+			}
+
+			server = httptest.NewServer(&cgi.Handler{
+				Path: gitPath,
+				Args: []string{"http-backend"},
+				Env: []string{
+					"GIT_PROJECT_ROOT=" + filepath.Dir(bareDir),
+					"GIT_HTTP_EXPORT_ALL=1",
+				},
+			})
+		})
+
+		it.After(func() {
+			if server != nil {
+				server.Close()
+			}
+		})
+
+		itListsTags(func() string { return server.URL + "/" + filepath.Base(bareDir) })
+	})
+
+	context("failure cases", func() {
+		it("will return an error for a missing local repository", func() {
+			_, err := GetAllVersionsFromTags(filepath.Join(t.TempDir(), "missing"))()
+			Expect(err).To(MatchError(ContainSubstring("unable to list tags of")))
+			Expect(err).To(MatchError(ContainSubstring("repository does not exist")))
+		})
+
+		it("will return an error for an unreachable remote", func() {
+			server := httptest.NewServer(nil)
+			server.Close()
+
+			_, err := GetAllVersionsFromTags(server.URL + "/project.git")()
+			Expect(err).To(MatchError(ContainSubstring("unable to list tags of " + strings.TrimSuffix(server.URL, "/"))))
+		})
+	})
+}
@@ -0,0 +1,14 @@
+package gitremote_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitFuncs(t *testing.T) {
+	suite := spec.New("gitremote", spec.Report(report.Terminal{}))
+	suite("Gitremote", testGitremote)
+	suite.Run(t)
+}
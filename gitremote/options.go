@@ -0,0 +1,40 @@
+package gitremote
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+)
+
+// Option configures how tags are listed and translated into versions
+type Option func(*sourceOptions)
+
+type sourceOptions struct {
+	versionExtractor versionology.VersionExtractor
+	auth             transport.AuthMethod
+}
+
+// WithVersionExtractor will use extractor to translate tag names into versions,
+// instead of versionology.DefaultVersionExtractor
+func WithVersionExtractor(extractor versionology.VersionExtractor) Option {
+	return func(o *sourceOptions) {
+		o.versionExtractor = extractor
+	}
+}
+
+// WithBasicAuth will authenticate to a smart HTTP remote with the username and password, or token
+func WithBasicAuth(username, password string) Option {
+	return func(o *sourceOptions) {
+		o.auth = &githttp.BasicAuth{Username: username, Password: password}
+	}
+}
+
+func newSourceOptions(opts []Option) sourceOptions {
+	options := sourceOptions{
+		versionExtractor: versionology.DefaultVersionExtractor,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
@@ -0,0 +1,3 @@
+// Package gitremote retrieves the versions of projects from the tags of any git remote, such as cgit, gitweb or Gitea
+// mirrors without a REST API, using the smart HTTP protocol, or from a repository at a local path.
+package gitremote
@@ -10,6 +10,7 @@ require (
 	github.com/Masterminds/semver/v3 v3.4.0
 	github.com/anchore/packageurl-go v0.1.1-0.20250220190351-d62adb6e1115
 	github.com/go-enry/go-license-detector/v4 v4.3.0
+	github.com/go-git/go-git/v5 v5.16.2
 	github.com/onsi/gomega v1.37.0
 	github.com/paketo-buildpacks/occam v0.28.0
 	github.com/paketo-buildpacks/packit/v2 v2.22.0
@@ -44,7 +45,6 @@ require (
 	github.com/gabriel-vasile/mimetype v1.4.9 // indirect
 	github.com/go-git/gcfg v1.5.1-0.20230307220236-3a3c6141e376 // indirect
 	github.com/go-git/go-billy/v5 v5.6.2 // indirect
-	github.com/go-git/go-git/v5 v5.16.2 // indirect
 	github.com/go-logr/logr v1.4.3 // indirect
 	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/go-ole/go-ole v1.3.0 // indirect
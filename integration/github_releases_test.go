@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/paketo-buildpacks/libdependency/github"
 	"github.com/paketo-buildpacks/libdependency/retrieve"
+	"github.com/paketo-buildpacks/libdependency/versionology"
 	"github.com/sclevine/spec"
 )
 
@@ -49,6 +50,24 @@ func testGithubReleases(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 
+		context("curl/curl with a version extractor", func() {
+			it.Before(func() {
+				extractor, err := versionology.NewRegexVersionExtractor(`^curl-(?P<version>\d+\.\d+\.\d+)$`)
+				Expect(err).NotTo(HaveOccurred())
+
+				allVersionsFunc = github.GetAllVersionsFromTags(os.Getenv("GIT_TOKEN"), "curl", "curl",
+					github.WithVersionExtractor(versionology.UnderscoresToDots(extractor)))
+			})
+
+			it("will return a list of github tags", func() {
+				// https://github.com/curl/curl/tags
+				fromGithub, err := allVersionsFunc()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fromGithub.GetVersionStrings()).To(ContainElements("7.78.0", "7.64.0"))
+			})
+		})
+
 		context("failure cases", func() {
 			context("non-existing org/space", func() {
 				it.Before(func() {
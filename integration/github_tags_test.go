@@ -0,0 +1,63 @@
+package integration_test
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/paketo-buildpacks/libdependency/github"
+	"github.com/paketo-buildpacks/libdependency/retrieve"
+	"github.com/sclevine/spec"
+)
+
+func testGithubTags(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect          = NewWithT(t).Expect
+		allVersionsFunc retrieve.GetAllVersionsFunc
+	)
+
+	context("GetAllVersionsFromTags", func() {
+		context("Masterminds/semver", func() {
+			it.Before(func() {
+				allVersionsFunc = github.GetAllVersionsFromTags(os.Getenv("GIT_TOKEN"), "Masterminds", "semver")
+			})
+
+			it("will return a list of github tags", func() {
+				// https://github.com/Masterminds/semver/tags
+				fromGithub, err := allVersionsFunc()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fromGithub).NotTo(BeNil())
+
+				Expect(fromGithub.GetVersionStrings()).To(ContainElements("3.2.0", "1.5.0"))
+			})
+		})
+
+		context("failure cases", func() {
+			context("non-existing org/space", func() {
+				it.Before(func() {
+					allVersionsFunc = github.GetAllVersionsFromTags(os.Getenv("GIT_TOKEN"), "a612403a", "99b59f037720")
+				})
+
+				it("will return error", func() {
+					_, err := allVersionsFunc()
+					Expect(err).To(HaveOccurred())
+				})
+			})
+		})
+	})
+
+	context("GetAllVersionsFromReleasesOrTags", func() {
+		context("Masterminds/semver", func() {
+			it.Before(func() {
+				allVersionsFunc = github.GetAllVersionsFromReleasesOrTags(os.Getenv("GIT_TOKEN"), "Masterminds", "semver")
+			})
+
+			it("will return a list of github releases", func() {
+				fromGithub, err := allVersionsFunc()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fromGithub.GetVersionStrings()).To(ContainElements("3.2.0"))
+			})
+		})
+	})
+}
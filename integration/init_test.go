@@ -10,5 +10,6 @@ import (
 func TestIntegration(t *testing.T) {
 	suite := spec.New("integration", spec.Report(report.Terminal{}))
 	suite("GithubReleases", testGithubReleases)
+	suite("GithubTags", testGithubTags)
 	suite.Run(t)
 }
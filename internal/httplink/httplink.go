@@ -0,0 +1,37 @@
+// Package httplink parses the RFC 5988 Link headers used to paginate REST APIs
+package httplink
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Next will return the URL of the "next" relation in the Link headers as specified by RFC 5988,
+// e.g. `<https://api.github.com/repositories/1/releases?page=2>; rel="next"`, or "" when there is no next page
+func Next(header http.Header) string {
+	for _, link := range header.Values("Link") {
+		for _, value := range strings.Split(link, ",") {
+			target, params, found := strings.Cut(strings.TrimSpace(value), ";")
+			if !found || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range strings.Split(params, ";") {
+				key, rel, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(key, "rel") {
+					continue
+				}
+
+				// rel may hold several space separated relation types, e.g. rel="next last"
+				if slices.ContainsFunc(strings.Fields(strings.Trim(rel, `"`)), func(relation string) bool {
+					return strings.EqualFold(relation, "next")
+				}) {
+					return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+				}
+			}
+		}
+	}
+
+	return ""
+}
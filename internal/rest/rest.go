@@ -0,0 +1,49 @@
+// Package rest holds the pieces shared by the clients of paginated REST APIs, such as those of GitHub and GitLab
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/paketo-buildpacks/libdependency/internal/httplink"
+)
+
+// Authenticator adds credentials to requests made to a REST API
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthenticatorFunc is an Authenticator implemented by a function
+type AuthenticatorFunc func(req *http.Request) error
+
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// GetJSONFunc will decode the JSON body at urlString into v and return the headers of the response
+type GetJSONFunc func(urlString string, v any) (http.Header, error)
+
+// GetAllPages will follow the "next" Link headers from urlString and return the items of every page,
+// stopping after maxPages pages unless maxPages is 0. The description names the items when stopping early.
+func GetAllPages[T any](getJSON GetJSONFunc, urlString string, maxPages int, description string) ([]T, error) {
+	allItems := make([]T, 0)
+
+	for page := 1; urlString != ""; page++ {
+		if maxPages > 0 && page > maxPages {
+			fmt.Printf("Stopping after %d pages of %s\n", maxPages, description)
+			break
+		}
+
+		var items []T
+		header, err := getJSON(urlString, &items)
+		if err != nil {
+			return nil, err
+		}
+
+		allItems = append(allItems, items...)
+
+		urlString = httplink.Next(header)
+	}
+
+	return allItems, nil
+}
@@ -0,0 +1,14 @@
+package npm_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitFuncs(t *testing.T) {
+	suite := spec.New("npm", spec.Report(report.Terminal{}))
+	suite("Npm", testNpm)
+	suite.Run(t)
+}
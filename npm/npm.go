@@ -0,0 +1,195 @@
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/libdependency/retrieve"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+)
+
+// abbreviatedMetadata is the media type of the abbreviated packument, which only has the fields needed to install
+// a package and is much smaller than the full packument, as documented by
+// https://github.com/npm/registry/blob/main/docs/responses/package-metadata.md
+const abbreviatedMetadata = "application/vnd.npm.install-v1+json; q=1.0, application/json; q=0.8, */*"
+
+// integrityStrength ranks the hash algorithms allowed in a subresource integrity
+var integrityStrength = map[string]int{
+	"sha256": 1,
+	"sha384": 2,
+	"sha512": 3,
+}
+
+type NpmPackumentDTO struct {
+	Name     string                   `json:"name"`
+	DistTags map[string]string        `json:"dist-tags"`
+	Versions map[string]NpmVersionDTO `json:"versions"`
+}
+
+type NpmVersionDTO struct {
+	Name       string      `json:"name"`
+	Version    string      `json:"version"`
+	Deprecated Deprecation `json:"deprecated,omitempty"`
+	Dist       NpmDistDTO  `json:"dist"`
+}
+
+type NpmDistDTO struct {
+	Tarball   string `json:"tarball"`
+	Shasum    string `json:"shasum"`
+	Integrity string `json:"integrity"`
+}
+
+// Deprecation is the deprecation message of a version, which is empty when the version is not deprecated
+type Deprecation string
+
+// UnmarshalJSON accepts `false` as well as a message, because some old versions were published with `"deprecated": false`
+func (d *Deprecation) UnmarshalJSON(data []byte) error {
+	var deprecated bool
+	if err := json.Unmarshal(data, &deprecated); err == nil {
+		*d = ""
+		if deprecated {
+			*d = "deprecated"
+		}
+		return nil
+	}
+
+	var message string
+	err := json.Unmarshal(data, &message)
+	if err != nil {
+		return err
+	}
+
+	*d = Deprecation(message)
+	return nil
+}
+
+// Checksum will return the strongest hash of the subresource integrity, e.g. "sha512-<base64>",
+// as a checksum of a dependency, e.g. "sha512:<hex>". Old versions without an integrity fall back to the SHA-1 shasum.
+func (d NpmDistDTO) Checksum() (string, error) {
+	if d.Integrity == "" {
+		if d.Shasum == "" {
+			return "", fmt.Errorf("no integrity or shasum for %s", d.Tarball)
+		}
+		return fmt.Sprintf("sha1:%s", d.Shasum), nil
+	}
+
+	strongest := ""
+	strongestHash := ""
+	for _, hash := range strings.Fields(d.Integrity) {
+		algorithm, digest, ok := strings.Cut(hash, "-")
+		if !ok {
+			continue
+		}
+
+		// options of a hash, e.g. sha512-<base64>?foo, are ignored as specified by https://www.w3.org/TR/SRI/
+		digest, _, _ = strings.Cut(digest, "?")
+		if integrityStrength[algorithm] > integrityStrength[strongest] {
+			strongest = algorithm
+			strongestHash = digest
+		}
+	}
+
+	if strongest == "" {
+		return "", fmt.Errorf("unsupported integrity '%s' for %s", d.Integrity, d.Tarball)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strongestHash)
+	if err != nil {
+		return "", fmt.Errorf("invalid integrity '%s' for %s: %w", d.Integrity, d.Tarball, err)
+	}
+
+	return fmt.Sprintf("%s:%s", strongest, hex.EncodeToString(decoded)), nil
+}
+
+// Release is a versionology.VersionFetcher for a version of an npm package, which exposes its tarball and integrity
+type Release struct {
+	NpmVersionDTO
+	version *semver.Version
+}
+
+// NewRelease will return a Release for the given version of a package and its parsed version
+func NewRelease(release NpmVersionDTO, version *semver.Version) Release {
+	return Release{
+		NpmVersionDTO: release,
+		version:       version,
+	}
+}
+
+func (r Release) Version() *semver.Version {
+	return r.version
+}
+
+// GetAllVersions will return a libdependency.VersionFetcherFunc that can retrieve all versions of the given package,
+// which may be scoped, e.g. `@yarnpkg/cli-dist`. Deprecated versions are excluded unless the options include them.
+func GetAllVersions(packageName string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return func() (versionology.VersionFetcherArray, error) {
+		options := newSourceOptions(opts)
+
+		packument, err := getPackument(packageName, options)
+		if err != nil {
+			return versionology.NewVersionFetcherArray(), err
+		}
+
+		allReleases := make([]Release, 0)
+		for key, release := range packument.Versions {
+			if release.Deprecated != "" && !options.includeDeprecated {
+				fmt.Printf("Skipping deprecated version '%s' of %s: %s\n", key, packageName, release.Deprecated)
+				continue
+			}
+
+			if version, err := semver.NewVersion(key); err != nil {
+				fmt.Printf("Skipping version '%s' of %s: %s\n", key, packageName, err)
+			} else {
+				allReleases = append(allReleases, NewRelease(release, version))
+			}
+		}
+
+		return versionology.SortedNewestFirst(allReleases), nil
+	}
+}
+
+// getPackument will return the abbreviated packument of the package.
+// The slash of a scoped package is escaped, as the npm CLI does.
+func getPackument(packageName string, options sourceOptions) (NpmPackumentDTO, error) {
+	urlString := fmt.Sprintf("%s/%s", options.registryURL, url.PathEscape(packageName))
+
+	req, err := http.NewRequest("GET", urlString, nil)
+	if err != nil {
+		return NpmPackumentDTO{}, err
+	}
+
+	req.Header.Set("Accept", abbreviatedMetadata)
+	if options.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", options.token))
+	}
+
+	res, err := options.httpClient.Do(req)
+	if err != nil {
+		return NpmPackumentDTO{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return NpmPackumentDTO{}, fmt.Errorf("failed to query url %s with: status code %d", urlString, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return NpmPackumentDTO{}, err
+	}
+
+	var packument NpmPackumentDTO
+	err = json.Unmarshal(body, &packument)
+	if err != nil {
+		return NpmPackumentDTO{}, err
+	}
+
+	return packument, nil
+}
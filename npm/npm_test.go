@@ -0,0 +1,137 @@
+package npm_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/paketo-buildpacks/libdependency/npm"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testNpm(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	var (
+		server   *httptest.Server
+		requests []*http.Request
+	)
+
+	it.Before(func() {
+		requests = nil
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /some-package", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			w.Header().Set("Content-Type", "application/vnd.npm.install-v1+json")
+			_, _ = fmt.Fprint(w, `{
+				"name": "some-package",
+				"dist-tags": {"latest": "2.0.0"},
+				"versions": {
+					"1.0.0": {
+						"name": "some-package",
+						"version": "1.0.0",
+						"deprecated": false,
+						"dist": {
+							"tarball": "https://registry.example.com/some-package/-/some-package-1.0.0.tgz",
+							"shasum": "da39a3ee5e6b4b0d3255bfef95601890afd80709"
+						}
+					},
+					"1.1.0": {
+						"name": "some-package",
+						"version": "1.1.0",
+						"deprecated": "1.1.0 is broken, use 2.0.0",
+						"dist": {"tarball": "https://registry.example.com/some-package/-/some-package-1.1.0.tgz"}
+					},
+					"2.0.0": {
+						"name": "some-package",
+						"version": "2.0.0",
+						"dist": {
+							"tarball": "https://registry.example.com/some-package/-/some-package-2.0.0.tgz",
+							"shasum": "da39a3ee5e6b4b0d3255bfef95601890afd80709",
+							"integrity": "sha1-2jmj7l5rSw0yVb/vlWAYkK/YBwk= sha512-z4PhNX7vuL3xVChQ1m2AB9Yg5AULVxXcg/SpIdNs6c5H0NE8XYXysP+DGNKHfuwvY7kxvUdBeoGlODJ6+SfaPg=="
+						}
+					},
+					"not-a-version": {"name": "some-package", "version": "not-a-version"}
+				}
+			}`)
+		})
+		mux.HandleFunc("GET /{scopedPackage}", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			if r.PathValue("scopedPackage") != "@some-scope/some-package" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"name": "@some-scope/some-package", "versions": {"3.0.0": {"version": "3.0.0"}}}`)
+		})
+
+		server = httptest.NewServer(mux)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	it("will return the versions newest first, without deprecated versions", func() {
+		versions, err := GetAllVersions("some-package", WithRegistryURL(server.URL+"/"), WithHTTPClient(server.Client()))()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions.GetVersionStrings()).To(Equal([]string{"2.0.0", "1.0.0"}))
+
+		release, ok := versions[0].(Release)
+		Expect(ok).To(BeTrue())
+		Expect(release.Dist.Tarball).To(Equal("https://registry.example.com/some-package/-/some-package-2.0.0.tgz"))
+		Expect(release.Dist.Integrity).To(HavePrefix("sha1-"))
+
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].Header.Get("Accept")).To(HavePrefix("application/vnd.npm.install-v1+json"))
+		Expect(requests[0].Header.Get("Authorization")).To(BeEmpty())
+	})
+
+	it("will include deprecated versions with the option", func() {
+		versions, err := GetAllVersions("some-package", WithRegistryURL(server.URL), WithDeprecated(true))()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions.GetVersionStrings()).To(Equal([]string{"2.0.0", "1.1.0", "1.0.0"}))
+		Expect(versions[1].(Release).Deprecated).To(Equal(Deprecation("1.1.0 is broken, use 2.0.0")))
+	})
+
+	it("will escape scoped packages and send the token", func() {
+		versions, err := GetAllVersions("@some-scope/some-package", WithRegistryURL(server.URL), WithToken("some-token"))()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions.GetVersionStrings()).To(Equal([]string{"3.0.0"}))
+
+		Expect(requests[0].URL.EscapedPath()).To(Equal("/@some-scope%2Fsome-package"))
+		Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer some-token"))
+	})
+
+	it("will return an error for an unsuccessful response", func() {
+		_, err := GetAllVersions("@some-scope/missing-package", WithRegistryURL(server.URL))()
+		Expect(err).To(MatchError(ContainSubstring("/@some-scope%2Fmissing-package with: status code 404")))
+	})
+
+	context("NpmDistDTO", func() {
+		it("will return the strongest hash of the integrity as a checksum", func() {
+			checksum, err := NpmDistDTO{
+				Integrity: "sha1-2jmj7l5rSw0yVb/vlWAYkK/YBwk= sha512-z4PhNX7vuL3xVChQ1m2AB9Yg5AULVxXcg/SpIdNs6c5H0NE8XYXysP+DGNKHfuwvY7kxvUdBeoGlODJ6+SfaPg== sha256-47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+			}.Checksum()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checksum).To(Equal("sha512:cf83e1357eefb8bdf1542850d66d8007d620e4050b5715dc83f4a921d36ce9ce47d0d13c5d85f2b0ff8318d2877eec2f63b931bd47417a81a538327af927da3e"))
+		})
+
+		it("will fall back to the shasum", func() {
+			checksum, err := NpmDistDTO{Shasum: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}.Checksum()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checksum).To(Equal("sha1:da39a3ee5e6b4b0d3255bfef95601890afd80709"))
+		})
+
+		it("will return an error without a usable hash", func() {
+			_, err := NpmDistDTO{Tarball: "some-tarball"}.Checksum()
+			Expect(err).To(MatchError("no integrity or shasum for some-tarball"))
+
+			_, err = NpmDistDTO{Tarball: "some-tarball", Integrity: "md5-abc"}.Checksum()
+			Expect(err).To(MatchError("unsupported integrity 'md5-abc' for some-tarball"))
+		})
+	})
+}
@@ -0,0 +1,58 @@
+package npm
+
+import (
+	"net/http"
+	"strings"
+)
+
+// DefaultRegistryURL is the URL of the public npm registry
+const DefaultRegistryURL = "https://registry.npmjs.org"
+
+// Option configures how the versions of a package are retrieved from a registry
+type Option func(*sourceOptions)
+
+type sourceOptions struct {
+	registryURL       string
+	token             string
+	httpClient        *http.Client
+	includeDeprecated bool
+}
+
+// WithRegistryURL will query the registry at registryURL instead of DefaultRegistryURL
+func WithRegistryURL(registryURL string) Option {
+	return func(o *sourceOptions) {
+		o.registryURL = strings.TrimSuffix(registryURL, "/")
+	}
+}
+
+// WithToken will send token as a bearer token, like the _authToken of an .npmrc
+func WithToken(token string) Option {
+	return func(o *sourceOptions) {
+		o.token = token
+	}
+}
+
+// WithHTTPClient will make requests with httpClient instead of a default http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *sourceOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithDeprecated will include deprecated versions, which are excluded by default
+func WithDeprecated(include bool) Option {
+	return func(o *sourceOptions) {
+		o.includeDeprecated = include
+	}
+}
+
+func newSourceOptions(opts []Option) sourceOptions {
+	options := sourceOptions{
+		registryURL: DefaultRegistryURL,
+		httpClient:  &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
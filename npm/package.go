@@ -0,0 +1,3 @@
+// Package npm retrieves the versions of packages published to the npm registry, or to any registry implementing
+// its API, together with the tarball and integrity of each version.
+package npm
@@ -0,0 +1,15 @@
+package pypi_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitFuncs(t *testing.T) {
+	suite := spec.New("pypi", spec.Report(report.Terminal{}))
+	suite("Pypi", testPypi)
+	suite("PEP440", testPEP440)
+	suite.Run(t)
+}
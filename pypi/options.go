@@ -0,0 +1,50 @@
+package pypi
+
+import (
+	"net/http"
+	"strings"
+)
+
+// DefaultIndexURL is the URL of the Python Package Index
+const DefaultIndexURL = "https://pypi.org"
+
+// Option configures how the versions of a package are retrieved from an index
+type Option func(*sourceOptions)
+
+type sourceOptions struct {
+	indexURL      string
+	httpClient    *http.Client
+	includeYanked bool
+}
+
+// WithIndexURL will query the index at indexURL instead of DefaultIndexURL
+func WithIndexURL(indexURL string) Option {
+	return func(o *sourceOptions) {
+		o.indexURL = strings.TrimSuffix(indexURL, "/")
+	}
+}
+
+// WithHTTPClient will make requests with httpClient instead of a default http.Client
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *sourceOptions) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithYanked will include yanked files, and releases whose files are all yanked, which are excluded by default
+func WithYanked(include bool) Option {
+	return func(o *sourceOptions) {
+		o.includeYanked = include
+	}
+}
+
+func newSourceOptions(opts []Option) sourceOptions {
+	options := sourceOptions{
+		indexURL:   DefaultIndexURL,
+		httpClient: &http.Client{},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
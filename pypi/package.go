@@ -0,0 +1,3 @@
+// Package pypi retrieves the versions of packages published to PyPI, or to any index implementing its JSON API,
+// together with the files of each version.
+package pypi
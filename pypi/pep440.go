@@ -0,0 +1,107 @@
+package pypi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// pep440Pattern matches the versions allowed by PEP 440, in any of their permitted spellings,
+// as specified by https://packaging.python.org/en/latest/specifications/version-specifiers/
+var pep440Pattern = regexp.MustCompile(`(?i)^\s*v?` +
+	`(?:(?P<epoch>\d+)!)?` +
+	`(?P<release>\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(?P<pre_label>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_number>\d*))?` +
+	`(?:-(?P<post_implicit>\d+)|[-_.]?(?P<post_label>post|rev|r)[-_.]?(?P<post_number>\d*))?` +
+	`(?:[-_.]?(?P<dev>dev)[-_.]?(?P<dev_number>\d*))?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?\s*$`)
+
+// preReleaseLabels maps the spellings of PEP 440 pre-release labels to semver pre-release identifiers,
+// which sort in the same order
+var preReleaseLabels = map[string]string{
+	"a":       "alpha",
+	"alpha":   "alpha",
+	"b":       "beta",
+	"beta":    "beta",
+	"c":       "rc",
+	"rc":      "rc",
+	"pre":     "rc",
+	"preview": "rc",
+}
+
+// ParsePEP440 will translate a PEP 440 version into a semver version that sorts in the same order:
+//
+//	1.2           becomes 1.2.0
+//	1.2.3rc1      becomes 1.2.3-rc.1 (and a1 becomes alpha.1, b1 becomes beta.1)
+//	1.2.3.dev4    becomes 1.2.3-0.dev.4, which sorts before the pre-releases of 1.2.3
+//	1.2.3a1.post2 becomes 1.2.3-alpha.1.post.2
+//
+// Semver cannot order post-releases of a release after the release but before the next one, nor development
+// releases of pre-releases and post-releases before the release they lead up to, so those cannot be translated.
+// Neither can versions with an epoch other than 0, with more than three release segments, or with a local label.
+func ParsePEP440(version string) (*semver.Version, error) {
+	matches := pep440Pattern.FindStringSubmatch(version)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid PEP 440 version '%s'", version)
+	}
+
+	group := func(name string) string {
+		return matches[pep440Pattern.SubexpIndex(name)]
+	}
+	number := func(name string) uint64 {
+		// an omitted number is 0, and the pattern only matches digits
+		value, _ := strconv.ParseUint(group(name), 10, 64)
+		return value
+	}
+
+	if epoch := group("epoch"); epoch != "" && number("epoch") != 0 {
+		return nil, fmt.Errorf("unable to translate PEP 440 version '%s' with epoch %s to semver", version, epoch)
+	}
+
+	if group("local") != "" {
+		return nil, fmt.Errorf("unable to translate PEP 440 version '%s' with local label to semver", version)
+	}
+
+	segments := strings.Split(group("release"), ".")
+	if len(segments) > 3 {
+		return nil, fmt.Errorf("unable to translate PEP 440 version '%s' with more than 3 release segments to semver", version)
+	}
+
+	release := make([]uint64, 3)
+	for i, segment := range segments {
+		release[i], _ = strconv.ParseUint(segment, 10, 64)
+	}
+
+	isPre := group("pre_label") != ""
+	isPost := group("post_implicit") != "" || group("post_label") != ""
+	isDev := group("dev") != ""
+
+	if isPost && !isPre {
+		return nil, fmt.Errorf("unable to translate PEP 440 post-release '%s' to semver", version)
+	}
+	if isDev && (isPre || isPost) {
+		return nil, fmt.Errorf("unable to translate PEP 440 development release '%s' of a pre-release or post-release to semver", version)
+	}
+
+	var prerelease []string
+	if isPre {
+		prerelease = append(prerelease, preReleaseLabels[strings.ToLower(group("pre_label"))], fmt.Sprint(number("pre_number")))
+	}
+	if isPost {
+		post := number("post_number")
+		if group("post_implicit") != "" {
+			post = number("post_implicit")
+		}
+		// a longer pre-release sorts after its prefix, and post.N before the next pre-release number or label
+		prerelease = append(prerelease, "post", fmt.Sprint(post))
+	}
+	if isDev {
+		// a numeric identifier sorts before alphanumeric ones, so development releases precede alpha, beta and rc
+		prerelease = append(prerelease, "0", "dev", fmt.Sprint(number("dev_number")))
+	}
+
+	return semver.New(release[0], release[1], release[2], strings.Join(prerelease, "."), ""), nil
+}
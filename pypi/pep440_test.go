@@ -0,0 +1,107 @@
+package pypi_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	. "github.com/paketo-buildpacks/libdependency/pypi"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testPEP440(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	context("ParsePEP440", func() {
+		it("will translate versions into semver", func() {
+			for pep440, expected := range map[string]string{
+				"1":             "1.0.0",
+				"1.2":           "1.2.0",
+				"v1.2.3":        "1.2.3",
+				"0!1.2.3":       "1.2.3",
+				"1.2.3a1":       "1.2.3-alpha.1",
+				"1.2.3.alpha.1": "1.2.3-alpha.1",
+				"1.2.3b2":       "1.2.3-beta.2",
+				"1.2.3-beta-2":  "1.2.3-beta.2",
+				"1.2.3rc3":      "1.2.3-rc.3",
+				"1.2.3c3":       "1.2.3-rc.3",
+				"1.2.3preview3": "1.2.3-rc.3",
+				"1.2.3rc":       "1.2.3-rc.0",
+				"1.2.3.dev4":    "1.2.3-0.dev.4",
+				"1.2.3dev":      "1.2.3-0.dev.0",
+				"1.2.3a1.post2": "1.2.3-alpha.1.post.2",
+				"1.2.3a1-2":     "1.2.3-alpha.1.post.2",
+				"1.2.3rc1.rev":  "1.2.3-rc.1.post.0",
+				"2023.7.22":     "2023.7.22",
+				" 1.2.3 ":       "1.2.3",
+				"1.2.3RC1":      "1.2.3-rc.1",
+			} {
+				version, err := ParsePEP440(pep440)
+				Expect(err).NotTo(HaveOccurred(), pep440)
+				Expect(version.String()).To(Equal(expected), pep440)
+			}
+		})
+
+		it("will keep the order of releases, pre-releases and development releases", func() {
+			ordered := []string{"0.9", "1.0.dev1", "1.0.dev2", "1.0a1", "1.0a1.post1", "1.0a2", "1.0b1", "1.0rc1", "1.0", "1.0.1", "1.1"}
+			for i := 1; i < len(ordered); i++ {
+				lower, err := ParsePEP440(ordered[i-1])
+				Expect(err).NotTo(HaveOccurred())
+				higher, err := ParsePEP440(ordered[i])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(higher.GreaterThan(lower)).To(BeTrue(), "%s > %s", ordered[i], ordered[i-1])
+			}
+		})
+
+		it("will return an error for versions that cannot be translated", func() {
+			_, err := ParsePEP440("not-a-version")
+			Expect(err).To(MatchError("invalid PEP 440 version 'not-a-version'"))
+
+			_, err = ParsePEP440("1!2.0")
+			Expect(err).To(MatchError("unable to translate PEP 440 version '1!2.0' with epoch 1 to semver"))
+
+			_, err = ParsePEP440("1.2.3.4")
+			Expect(err).To(MatchError("unable to translate PEP 440 version '1.2.3.4' with more than 3 release segments to semver"))
+
+			_, err = ParsePEP440("1.2.3+ubuntu.1")
+			Expect(err).To(MatchError("unable to translate PEP 440 version '1.2.3+ubuntu.1' with local label to semver"))
+		})
+
+		it("will return an error for versions whose order semver cannot keep", func() {
+			for _, pep440 := range []string{"1.2.3.post5", "1.2.3-5", "1.2.3.rev5", "1.2.3.post5.dev1"} {
+				_, err := ParsePEP440(pep440)
+				Expect(err).To(MatchError(fmt.Sprintf("unable to translate PEP 440 post-release '%s' to semver", pep440)))
+			}
+
+			for _, pep440 := range []string{"1.2.3rc1.dev2", "1.2.3a1.post2.dev3"} {
+				_, err := ParsePEP440(pep440)
+				Expect(err).To(MatchError(fmt.Sprintf("unable to translate PEP 440 development release '%s' of a pre-release or post-release to semver", pep440)))
+			}
+		})
+
+		it("will keep the order when filtering upstream versions", func() {
+			parse := func(versions ...string) versionology.VersionFetcherArray {
+				array := versionology.NewVersionFetcherArray()
+				for _, pep440 := range versions {
+					version, err := ParsePEP440(pep440)
+					Expect(err).NotTo(HaveOccurred())
+					array = append(array, versionology.NewSimpleVersionFetcher(version))
+				}
+				return array
+			}
+
+			constraint, err := semver.NewConstraint("~1.2.3-0")
+			Expect(err).NotTo(HaveOccurred())
+
+			filtered := versionology.FilterUpstreamVersionsByConstraints("dep",
+				parse("1.2.3", "1.2.3rc1", "1.2.3b1", "1.2.3a1.post1", "1.2.3a1", "1.2.3.dev4"),
+				[]versionology.Constraint{{Constraint: constraint, Patches: 10}},
+				parse("1.2.3a1"))
+
+			Expect(filtered.GetVersionStrings()).To(Equal([]string{"1.2.3", "1.2.3-rc.1", "1.2.3-beta.1", "1.2.3-alpha.1.post.1"}))
+		})
+	})
+}
@@ -0,0 +1,177 @@
+package pypi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/libdependency/collections"
+	"github.com/paketo-buildpacks/libdependency/retrieve"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+)
+
+// PackageTypeSdist is the packagetype of a source distribution, as opposed to e.g. "bdist_wheel"
+const PackageTypeSdist = "sdist"
+
+type PypiProjectDTO struct {
+	Info     PypiInfoDTO              `json:"info"`
+	Releases map[string][]PypiFileDTO `json:"releases"`
+}
+
+type PypiInfoDTO struct {
+	Name string `json:"name"`
+}
+
+type PypiFileDTO struct {
+	Filename       string         `json:"filename"`
+	URL            string         `json:"url"`
+	PackageType    string         `json:"packagetype"`
+	Digests        PypiDigestsDTO `json:"digests"`
+	RequiresPython string         `json:"requires_python"`
+	UploadTime     time.Time      `json:"upload_time_iso_8601"`
+	Yanked         bool           `json:"yanked"`
+	YankedReason   string         `json:"yanked_reason"`
+}
+
+type PypiDigestsDTO struct {
+	SHA256 string `json:"sha256"`
+}
+
+// Checksum will return the SHA256 digest of the file as a checksum of a dependency, e.g. "sha256:<hex>"
+func (f PypiFileDTO) Checksum() string {
+	return fmt.Sprintf("sha256:%s", f.Digests.SHA256)
+}
+
+// Release is a versionology.VersionFetcher for a release of a PyPI package, which exposes its files.
+// It also implements versionology.ReleaseDateFetcher using the upload time of its first file.
+type Release struct {
+	// PEP440Version is the version as published, e.g. 1.2rc1
+	PEP440Version string
+	Files         []PypiFileDTO
+	version       *semver.Version
+}
+
+// NewRelease will return a Release for the given PEP 440 version of a package and its files
+func NewRelease(pep440Version string, files []PypiFileDTO) (Release, error) {
+	version, err := ParsePEP440(pep440Version)
+	if err != nil {
+		return Release{}, err
+	}
+
+	return Release{
+		PEP440Version: pep440Version,
+		Files:         files,
+		version:       version,
+	}, nil
+}
+
+func (r Release) Version() *semver.Version {
+	return r.version
+}
+
+func (r Release) ReleaseDate() time.Time {
+	var earliest time.Time
+	for _, file := range r.Files {
+		if earliest.IsZero() || file.UploadTime.Before(earliest) {
+			earliest = file.UploadTime
+		}
+	}
+	return earliest
+}
+
+// Sdist will return the source distribution of the release, if it has one
+func (r Release) Sdist() (PypiFileDTO, bool) {
+	for _, file := range r.Files {
+		if file.PackageType == PackageTypeSdist {
+			return file, true
+		}
+	}
+	return PypiFileDTO{}, false
+}
+
+// GetAllVersions will return a libdependency.VersionFetcherFunc that can retrieve all versions of the given package
+// from the JSON API of the index, as documented by https://docs.pypi.org/api/json/.
+// Yanked files, and releases whose files are all yanked, are excluded unless the options include them.
+// Releases without files, and releases whose versions cannot be translated by ParsePEP440, are skipped.
+func GetAllVersions(packageName string, opts ...Option) retrieve.GetAllVersionsFunc {
+	return func() (versionology.VersionFetcherArray, error) {
+		options := newSourceOptions(opts)
+
+		project, err := getProject(packageName, options)
+		if err != nil {
+			return versionology.NewVersionFetcherArray(), err
+		}
+
+		allReleases := make([]Release, 0)
+		for pep440Version, files := range project.Releases {
+			if !options.includeYanked {
+				files = collections.FilterFunc(files, func(file PypiFileDTO) bool {
+					return !file.Yanked
+				})
+			}
+
+			if len(files) == 0 {
+				fmt.Printf("Skipping version '%s' of %s: no files, or all files are yanked\n", pep440Version, packageName)
+				continue
+			}
+
+			if release, err := NewRelease(pep440Version, files); err != nil {
+				fmt.Printf("Skipping version '%s' of %s: %s\n", pep440Version, packageName, err)
+			} else {
+				allReleases = append(allReleases, release)
+			}
+		}
+
+		return versionology.SortedNewestFirst(allReleases), nil
+	}
+}
+
+// nameSeparators are the runs of characters replaced by a dash to normalize a package name
+var nameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// NormalizeName will normalize the package name as specified by
+// https://packaging.python.org/en/latest/specifications/name-normalization/
+func NormalizeName(packageName string) string {
+	return strings.ToLower(nameSeparators.ReplaceAllString(packageName, "-"))
+}
+
+// getProject will return the JSON metadata of the package
+func getProject(packageName string, options sourceOptions) (PypiProjectDTO, error) {
+	urlString := fmt.Sprintf("%s/pypi/%s/json", options.indexURL, url.PathEscape(NormalizeName(packageName)))
+
+	req, err := http.NewRequest("GET", urlString, nil)
+	if err != nil {
+		return PypiProjectDTO{}, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	res, err := options.httpClient.Do(req)
+	if err != nil {
+		return PypiProjectDTO{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return PypiProjectDTO{}, fmt.Errorf("failed to query url %s with: status code %d", urlString, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return PypiProjectDTO{}, err
+	}
+
+	var project PypiProjectDTO
+	err = json.Unmarshal(body, &project)
+	if err != nil {
+		return PypiProjectDTO{}, err
+	}
+
+	return project, nil
+}
@@ -0,0 +1,140 @@
+package pypi_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	. "github.com/paketo-buildpacks/libdependency/pypi"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testPypi(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	var (
+		server   *httptest.Server
+		requests []*http.Request
+	)
+
+	it.Before(func() {
+		requests = nil
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /pypi/some-package/json", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			_, _ = fmt.Fprint(w, `{
+				"info": {"name": "Some_Package"},
+				"releases": {
+					"1.0": [
+						{
+							"filename": "some_package-1.0-py3-none-any.whl",
+							"url": "https://files.example.com/some_package-1.0-py3-none-any.whl",
+							"packagetype": "bdist_wheel",
+							"digests": {"sha256": "wheel-sha"},
+							"upload_time_iso_8601": "2025-01-02T10:00:00.000000Z",
+							"yanked": false
+						},
+						{
+							"filename": "some_package-1.0.tar.gz",
+							"url": "https://files.example.com/some_package-1.0.tar.gz",
+							"packagetype": "sdist",
+							"digests": {"sha256": "sdist-sha"},
+							"upload_time_iso_8601": "2025-01-02T09:00:00.000000Z",
+							"yanked": false
+						}
+					],
+					"1.0.post1": [
+						{"filename": "some_package-1.0.post1.tar.gz", "packagetype": "sdist", "digests": {"sha256": "post1-sha"}}
+					],
+					"1.0.post2": [
+						{"filename": "some_package-1.0.post2.tar.gz", "packagetype": "sdist", "digests": {"sha256": "post2-sha"}}
+					],
+					"1.1": [
+						{"filename": "some_package-1.1.tar.gz", "packagetype": "sdist", "yanked": true, "yanked_reason": "broken"}
+					],
+					"1.2rc1": [
+						{"filename": "some_package-1.2rc1-py3-none-any.whl", "packagetype": "bdist_wheel", "yanked": false}
+					],
+					"1.2.dev1": [
+						{"filename": "some_package-1.2.dev1.tar.gz", "packagetype": "sdist"}
+					],
+					"0.9": [],
+					"1.2.3.4": [
+						{"filename": "some_package-1.2.3.4.tar.gz", "packagetype": "sdist"}
+					]
+				}
+			}`)
+		})
+
+		server = httptest.NewServer(mux)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	it("will return the releases newest first, without yanked releases", func() {
+		versions, err := GetAllVersions("Some_Package", WithIndexURL(server.URL+"/"), WithHTTPClient(server.Client()))()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions.GetVersionStrings()).To(Equal([]string{"1.2.0-rc.1", "1.2.0-0.dev.1", "1.0.0"}))
+
+		Expect(requests).To(HaveLen(1))
+		Expect(requests[0].URL.Path).To(Equal("/pypi/some-package/json"))
+
+		release, ok := versions[2].(Release)
+		Expect(ok).To(BeTrue())
+		Expect(release.PEP440Version).To(Equal("1.0"))
+		Expect(release.Files).To(HaveLen(2))
+		Expect(release.ReleaseDate()).To(Equal(time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)))
+
+		sdist, ok := release.Sdist()
+		Expect(ok).To(BeTrue())
+		Expect(sdist.URL).To(Equal("https://files.example.com/some_package-1.0.tar.gz"))
+		Expect(sdist.Checksum()).To(Equal("sha256:sdist-sha"))
+
+		_, ok = versions[0].(Release).Sdist()
+		Expect(ok).To(BeFalse())
+	})
+
+	it("will return the releases newer than existing versions when filtered by constraints", func() {
+		versions, err := GetAllVersions("some-package", WithIndexURL(server.URL))()
+		Expect(err).NotTo(HaveOccurred())
+
+		constraint, err := semver.NewConstraint(">=1.0.0-0")
+		Expect(err).NotTo(HaveOccurred())
+
+		dependencies, err := versionology.NewSimpleVersionFetcherArray("1.0.0")
+		Expect(err).NotTo(HaveOccurred())
+
+		filtered := versionology.FilterUpstreamVersionsByConstraints("some-package", versions,
+			[]versionology.Constraint{{Constraint: constraint, Patches: 10}}, dependencies)
+		Expect(filtered.GetVersionStrings()).To(Equal([]string{"1.2.0-rc.1", "1.2.0-0.dev.1"}))
+	})
+
+	it("will include yanked releases with the option", func() {
+		versions, err := GetAllVersions("some-package", WithIndexURL(server.URL), WithYanked(true))()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions.GetVersionStrings()).To(ContainElement("1.1.0"))
+	})
+
+	it("will return an error for an unsuccessful response", func() {
+		_, err := GetAllVersions("missing-package", WithIndexURL(server.URL))()
+		Expect(err).To(MatchError(ContainSubstring("/pypi/missing-package/json with: status code 404")))
+	})
+
+	context("NormalizeName", func() {
+		it("will normalize the package name", func() {
+			Expect(NormalizeName("Friendly-Bard")).To(Equal("friendly-bard"))
+			Expect(NormalizeName("FRIENDLY_BARD")).To(Equal("friendly-bard"))
+			Expect(NormalizeName("friendly.bard")).To(Equal("friendly-bard"))
+			Expect(NormalizeName("Friendly-._Bard")).To(Equal("friendly-bard"))
+		})
+	})
+}
@@ -1,6 +1,8 @@
 package retrieve
 
 import (
+	"fmt"
+
 	"github.com/paketo-buildpacks/libdependency/buildpack_config"
 	"github.com/paketo-buildpacks/libdependency/versionology"
 	"github.com/paketo-buildpacks/packit/v2/cargo"
@@ -8,10 +10,12 @@ import (
 
 // GetNewVersionsForId will return only those versions with the following properties:
 // - returned by getAllVersions
+// - released at least the minimum age ago, when using WithMinimumAge
 // - match constraints
 // - newer than all existing dependencies
-func GetNewVersionsForId(id string, config cargo.Config, getAllVersions GetAllVersionsFunc) (versionology.VersionFetcherArray, error) {
+func GetNewVersionsForId(id string, config cargo.Config, getAllVersions GetAllVersionsFunc, opts ...Option) (versionology.VersionFetcherArray, error) {
 	empty := versionology.NewVersionFetcherArray()
+	options := newOptions(opts)
 
 	allVersions, err := getAllVersions()
 	if err != nil {
@@ -20,6 +24,14 @@ func GetNewVersionsForId(id string, config cargo.Config, getAllVersions GetAllVe
 
 	versionology.LogAllVersions(id, "from upstream", allVersions)
 
+	if options.MinimumAge > 0 {
+		var coolingDown versionology.VersionFetcherArray
+		allVersions, coolingDown = versionology.FilterByMinimumAge(allVersions, options.MinimumAge, options.Now())
+		if len(coolingDown) > 0 {
+			versionology.LogAllVersions(id, fmt.Sprintf("cooling down, released less than %s ago", options.MinimumAge), coolingDown)
+		}
+	}
+
 	dependencies, err := buildpack_config.GetDependenciesById(id, config)
 	if err != nil { //untested
 		return empty, err
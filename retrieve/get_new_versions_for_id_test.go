@@ -4,9 +4,11 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/paketo-buildpacks/libdependency/buildpack_config"
+	"github.com/paketo-buildpacks/libdependency/github"
 	"github.com/paketo-buildpacks/libdependency/retrieve"
 	"github.com/paketo-buildpacks/libdependency/versionology"
 	"github.com/sclevine/spec"
@@ -109,6 +111,30 @@ func testGetNewVersionsForId(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 
+		context("when using a minimum age", func() {
+			it("will hold back versions that are cooling down", func() {
+				config, err := buildpack_config.ParseBuildpackToml(filepath.Join("testdata", "empty", "buildpack.toml"))
+				Expect(err).NotTo(HaveOccurred())
+
+				now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+				newVersions, err := retrieve.GetNewVersionsForId(
+					"id",
+					config,
+					func() (versionology.VersionFetcherArray, error) {
+						return versionology.VersionFetcherArray{
+							github.NewRelease(github.GithubReleaseNamesDTO{PublishedAt: now.AddDate(0, 0, -10)}, semver.MustParse("1.0.0")),
+							github.NewRelease(github.GithubReleaseNamesDTO{PublishedAt: now.AddDate(0, 0, -2)}, semver.MustParse("2.0.0")),
+							versionology.NewSimpleVersionFetcher(semver.MustParse("3.0.0")),
+						}, nil
+					},
+					retrieve.WithMinimumAge(3*24*time.Hour),
+					retrieve.WithNow(func() time.Time { return now }),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(versionology.VersionFetcherToString(newVersions)).To(ConsistOf("1.0.0", "3.0.0"))
+			})
+		})
+
 		context("failure cases", func() {
 			context("when getNewVersions returns an error", func() {
 				it("will return the error", func() {
@@ -0,0 +1,54 @@
+package retrieve
+
+import (
+	"github.com/paketo-buildpacks/libdependency/buildpack_config"
+	"github.com/paketo-buildpacks/libdependency/collections"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+)
+
+// GetRemovedVersionsForId will return those dependencies in the buildpack.toml with the given id
+// whose version is no longer returned by getAllVersions, e.g. because upstream deleted or yanked the release.
+func GetRemovedVersionsForId(id string, config cargo.Config, getAllVersions GetAllVersionsFunc) ([]versionology.Dependency, error) {
+	allVersions, err := getAllVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	versionology.LogAllVersions(id, "from upstream", allVersions)
+
+	dependencies, err := buildpack_config.GetDependenciesById(id, config)
+	if err != nil { //untested
+		return nil, err
+	}
+
+	removed := collections.FilterFunc(dependencies, func(dependency versionology.Dependency) bool {
+		return !allVersions.Contains(dependency.Version())
+	})
+
+	versionology.LogAllVersions(id, "no longer found upstream", collections.TransformFunc(removed, func(dependency versionology.Dependency) versionology.VersionFetcher {
+		return dependency
+	}))
+
+	return removed, nil
+}
+
+// NewRemovalMetadata is an entrypoint for a buildpack to find dependencies that have disappeared upstream.
+// It takes the same inputs as NewMetadata, and writes the dependencies of the buildpack.toml that are no longer
+// returned by getAllVersions to the output, using the same JSON format as the metadata.json written by NewMetadata.
+func NewRemovalMetadata(id string, getAllVersions GetAllVersionsFunc) {
+	buildpackTomlPath, output := FetchArgs()
+	validate(buildpackTomlPath, output)
+
+	config, err := buildpack_config.ParseBuildpackToml(buildpackTomlPath)
+	if err != nil {
+		panic(err)
+	}
+
+	removed, err := GetRemovedVersionsForId(id, config, getAllVersions)
+	if err != nil {
+		panic(err)
+	}
+
+	writeWorkflowJson(output, removed)
+}
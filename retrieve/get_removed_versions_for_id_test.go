@@ -0,0 +1,68 @@
+package retrieve_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/paketo-buildpacks/libdependency/buildpack_config"
+	"github.com/paketo-buildpacks/libdependency/retrieve"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/paketo-buildpacks/occam/matchers"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testGetRemovedVersionsForId(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	context("GetRemovedVersionsForId", func() {
+		it("will return the dependencies no longer found upstream", func() {
+			config, err := buildpack_config.ParseBuildpackToml(filepath.Join("testdata", "bundler", "buildpack.toml"))
+			Expect(err).NotTo(HaveOccurred())
+
+			removed, err := retrieve.GetRemovedVersionsForId(
+				"bundler",
+				config,
+				func() (versionology.VersionFetcherArray, error) {
+					return versionology.NewSimpleVersionFetcherArray("1.17.3", "2.3.16", "2.3.17")
+				},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versionology.Versions(removed)).To(ConsistOf("2.3.15"))
+			Expect(removed[0].URI).To(Equal("https://deps.paketo.io/bundler/bundler_2.3.15_linux_noarch_bionic_03c92b21.tgz"))
+		})
+
+		context("failure cases", func() {
+			it("will return the error from getAllVersions", func() {
+				config, err := buildpack_config.ParseBuildpackToml(filepath.Join("testdata", "bundler", "buildpack.toml"))
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = retrieve.GetRemovedVersionsForId(
+					"bundler",
+					config,
+					func() (versionology.VersionFetcherArray, error) {
+						return nil, errors.New("hi")
+					},
+				)
+				Expect(err).To(MatchError("hi"))
+			})
+		})
+	})
+
+	context("NewRemovalMetadata", func() {
+		it("will write the removed dependencies in the metadata.json format", func() {
+			output := filepath.Join(t.TempDir(), "removed.json")
+			retrieve.FetchArgs = func() (string, string) {
+				return filepath.Join("testdata", "happy_path", "buildpack.toml"), output
+			}
+
+			retrieve.NewRemovalMetadata("fake-dependency-id", func() (versionology.VersionFetcherArray, error) {
+				return versionology.NewSimpleVersionFetcherArray("1.1.0", "1.2.0")
+			})
+
+			Expect(output).To(matchers.BeAFileMatching(MatchJSON(`[{"id":"fake-dependency-id","version":"1.0.0"}]`)))
+		})
+	})
+}
@@ -12,6 +12,7 @@ func TestUnitRetrieve(t *testing.T) {
 	suite("NewMetadata", testNewMetadata, spec.Sequential())
 	suite("NewMetadataWithPlatforms", testNewMetadataWithPlatforms, spec.Sequential())
 	suite("GetNewVersionsForId", testGetNewVersionsForId, spec.Sequential())
+	suite("GetRemovedVersionsForId", testGetRemovedVersionsForId, spec.Sequential())
 	suite("purl", testPurl)
 	suite.Run(t)
 }
@@ -0,0 +1,42 @@
+package retrieve
+
+import (
+	"time"
+)
+
+// Options contains the optional settings for GetNewVersionsForId, NewMetadata and NewMetadataWithPlatforms
+type Options struct {
+	// MinimumAge holds back versions that were released upstream more recently than this.
+	// It only applies to versions that implement versionology.ReleaseDateFetcher.
+	MinimumAge time.Duration
+
+	// Now returns the current time, and exists for testing purposes
+	Now func() time.Time
+}
+
+// Option sets a field of Options
+type Option func(*Options)
+
+// WithMinimumAge will hold back versions released less than minimumAge ago, logging them as "cooling down"
+func WithMinimumAge(minimumAge time.Duration) Option {
+	return func(o *Options) {
+		o.MinimumAge = minimumAge
+	}
+}
+
+// WithNow will replace the current time, and exists for testing purposes
+func WithNow(now func() time.Time) Option {
+	return func(o *Options) {
+		o.Now = now
+	}
+}
+
+func newOptions(opts []Option) Options {
+	options := Options{
+		Now: time.Now,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
@@ -18,6 +18,22 @@ type Platform struct {
 	Arch string `json:"arch"`
 }
 
+// NewPlatform will translate a versionology.Target into a Platform
+func NewPlatform(target versionology.Target) Platform {
+	return Platform{
+		OS:   target.OS,
+		Arch: target.Arch,
+	}
+}
+
+// Target will translate the Platform into a versionology.Target
+func (p Platform) Target() versionology.Target {
+	return versionology.Target{
+		OS:   p.OS,
+		Arch: p.Arch,
+	}
+}
+
 // GetAllVersionsFunc is a function type that buildpack authors will implement and pass in to NewMetadata.
 // The implementation should return all known upstream versions of a dependency.
 // Buildpack authors can choose the source of these versions. Some examples include:
@@ -38,7 +54,7 @@ type TransformsPlatformsFunc func(platforms []Platform) []Platform
 // NewMetadata is the entrypoint for a buildpack to retrieve new versions and the metadata thereof.
 // Given a way to retrieve all versions (getNewVersions) and a way to generate metadata for a version (generateMetadata),
 // this function will take in the dependency workflow inputs and the dependency workflow outputs
-func NewMetadata(id string, getAllVersions GetAllVersionsFunc, generateMetadata GenerateMetadataFunc) {
+func NewMetadata(id string, getAllVersions GetAllVersionsFunc, generateMetadata GenerateMetadataFunc, opts ...Option) {
 	buildpackTomlPath, output := FetchArgs()
 	validate(buildpackTomlPath, output)
 
@@ -47,26 +63,17 @@ func NewMetadata(id string, getAllVersions GetAllVersionsFunc, generateMetadata
 		panic(err)
 	}
 
-	newVersions, err := GetNewVersionsForId(id, config, getAllVersions)
+	newVersions, err := GetNewVersionsForId(id, config, getAllVersions, opts...)
 	if err != nil {
 		panic(err)
 	}
 
 	dependencies := GenerateAllMetadata(newVersions, generateMetadata)
 
-	metadataJson, err := toWorkflowJson(dependencies)
-	if err != nil {
-		panic(fmt.Errorf("unable to marshall metadata json, with error=%w", err))
-	}
-
-	if err = os.WriteFile(output, []byte(metadataJson), os.ModePerm); err != nil {
-		panic(fmt.Errorf("cannot write to %s: %w", output, err))
-	} else {
-		fmt.Printf("Wrote metadata to %s\n", output)
-	}
+	writeWorkflowJson(output, dependencies)
 }
 
-func NewMetadataWithPlatforms(id string, getAllVersions GetAllVersionsFunc, generateMetadata GenerateMetadataWithPlatformFunc, transformsPlatforms TransformsPlatformsFunc) {
+func NewMetadataWithPlatforms(id string, getAllVersions GetAllVersionsFunc, generateMetadata GenerateMetadataWithPlatformFunc, transformsPlatforms TransformsPlatformsFunc, opts ...Option) {
 	buildpackTomlPath, output := FetchArgs()
 	validate(buildpackTomlPath, output)
 
@@ -75,7 +82,7 @@ func NewMetadataWithPlatforms(id string, getAllVersions GetAllVersionsFunc, gene
 		panic(err)
 	}
 
-	newVersions, err := GetNewVersionsForId(id, config, getAllVersions)
+	newVersions, err := GetNewVersionsForId(id, config, getAllVersions, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -91,16 +98,7 @@ func NewMetadataWithPlatforms(id string, getAllVersions GetAllVersionsFunc, gene
 		dependencies = append(dependencies, GenerateAllMetadataWithPlatform(newVersions, generateMetadata, platform)...)
 	}
 
-	metadataJson, err := toWorkflowJson(dependencies)
-	if err != nil {
-		panic(fmt.Errorf("unable to marshall metadata json, with error=%w", err))
-	}
-
-	if err = os.WriteFile(output, []byte(metadataJson), os.ModePerm); err != nil {
-		panic(fmt.Errorf("cannot write to %s: %w", output, err))
-	} else {
-		fmt.Printf("Wrote metadata to %s\n", output)
-	}
+	writeWorkflowJson(output, dependencies)
 }
 
 // toWorkflowJson will return a string containing JSON formatted as a GitHub workflow expects, with
@@ -116,6 +114,20 @@ func toWorkflowJson(item any) (string, error) {
 	}
 }
 
+// writeWorkflowJson will write the dependencies to output using toWorkflowJson
+func writeWorkflowJson(output string, dependencies []versionology.Dependency) {
+	metadataJson, err := toWorkflowJson(dependencies)
+	if err != nil {
+		panic(fmt.Errorf("unable to marshall metadata json, with error=%w", err))
+	}
+
+	if err = os.WriteFile(output, []byte(metadataJson), os.ModePerm); err != nil {
+		panic(fmt.Errorf("cannot write to %s: %w", output, err))
+	} else {
+		fmt.Printf("Wrote metadata to %s\n", output)
+	}
+}
+
 func getPlatformsFromConfig(config cargo.Config) []Platform {
 	var platforms []Platform
 
@@ -140,7 +152,7 @@ func GenerateAllMetadata(newVersions versionology.VersionFetcherArray, generateM
 
 		var targets []string
 		for _, metadatum := range metadata {
-			targets = append(targets, metadatum.Target)
+			targets = append(targets, metadatum.Target.String())
 		}
 		fmt.Printf("Generating metadata for %s, with targets [%s]\n", version.Version().String(), strings.Join(targets, ", "))
 		dependencies = append(dependencies, metadata...)
@@ -159,7 +171,7 @@ func GenerateAllMetadataWithPlatform(newVersions versionology.VersionFetcherArra
 
 		var targets []string
 		for _, metadatum := range metadata {
-			targets = append(targets, metadatum.Target)
+			targets = append(targets, metadatum.Target.String())
 		}
 
 		fmt.Printf("Generating metadata for %s, platform %s/%s, with stacks [%s]\n",
@@ -1,16 +1,99 @@
 package versionology
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/libdependency/collections"
 	"github.com/paketo-buildpacks/packit/v2/cargo"
 )
 
+// PatchWindow determines how Constraint.Patches is applied to the versions that satisfy a constraint
+type PatchWindow string
+
+const (
+	// PatchWindowConstraint keeps the newest Patches versions across the whole constraint. This is the default.
+	PatchWindowConstraint PatchWindow = ""
+
+	// PatchWindowMinor keeps the newest Patches versions for each major.minor line inside the constraint.
+	// Only the lines at or above the oldest line with an existing dependency are considered, so that switching
+	// a constraint to this window does not bring back lines that were dropped. Without existing dependencies,
+	// every line is considered.
+	PatchWindowMinor PatchWindow = "minor"
+)
+
+// NewPatchWindow will translate a string into a PatchWindow, returning an error for unknown values
+func NewPatchWindow(window string) (PatchWindow, error) {
+	switch PatchWindow(window) {
+	case PatchWindowConstraint, "constraint":
+		return PatchWindowConstraint, nil
+	case PatchWindowMinor:
+		return PatchWindowMinor, nil
+	default:
+		return PatchWindowConstraint, fmt.Errorf("unknown patch window '%s', must be one of 'constraint' or 'minor'", window)
+	}
+}
+
+// Exclusions is an immutable list of versions or version ranges that a Constraint must never select.
+// Constraint refers to it through a pointer so that Constraint remains comparable and usable as a map key.
+type Exclusions struct {
+	constraints []*semver.Constraints
+}
+
+// NewExclusions will parse each exclusion, which is either an exact version (e.g. "3.11.0")
+// or a semver range (e.g. ">=2.0.0-0 <2.0.3")
+func NewExclusions(exclusions ...string) (*Exclusions, error) {
+	parsed := make([]*semver.Constraints, 0, len(exclusions))
+	for _, exclusion := range exclusions {
+		semverExclusion, err := semver.NewConstraint(exclusion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclusion '%s': %w", exclusion, err)
+		}
+		parsed = append(parsed, semverExclusion)
+	}
+
+	return &Exclusions{constraints: parsed}, nil
+}
+
+// Len returns the number of exclusions, which is 0 for nil Exclusions
+func (e *Exclusions) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.constraints)
+}
+
+// All returns a copy of the exclusions
+func (e *Exclusions) All() []*semver.Constraints {
+	if e == nil {
+		return nil
+	}
+	return append([]*semver.Constraints(nil), e.constraints...)
+}
+
+// Excludes returns the first exclusion that the version satisfies, or nil if the version is not excluded
+func (e *Exclusions) Excludes(version *semver.Version) *semver.Constraints {
+	if e == nil {
+		return nil
+	}
+	for _, exclusion := range e.constraints {
+		if exclusion.Check(version) {
+			return exclusion
+		}
+	}
+	return nil
+}
+
 // Constraint largely mimics cargo.ConfigMetadataDependencyConstraint but has
 // a semver.Constraints instead of a string
 type Constraint struct {
 	Constraint *semver.Constraints
 	ID         string
 	Patches    int
+	Exclusions *Exclusions
+	Window     PatchWindow
+	Backfill   bool
 }
 
 // NewConstraint will translate a cargo.ConfigMetadataDependencyConstraint into a Constraint
@@ -28,7 +111,167 @@ func NewConstraint(c cargo.ConfigMetadataDependencyConstraint) (Constraint, erro
 	}, nil
 }
 
+// NewConstraintWithExclusions will translate a cargo.ConfigMetadataDependencyConstraint into a Constraint
+// that will never select a version matching any of the exclusions.
+// Each exclusion is either an exact version (e.g. "3.11.0") or a semver range (e.g. ">=2.0.0-0 <2.0.3").
+func NewConstraintWithExclusions(c cargo.ConfigMetadataDependencyConstraint, exclusions ...string) (Constraint, error) {
+	constraint, err := NewConstraint(c)
+	if err != nil {
+		return Constraint{}, err
+	}
+
+	constraint.Exclusions, err = NewExclusions(exclusions...)
+	if err != nil {
+		return Constraint{}, err
+	}
+
+	return constraint, nil
+}
+
 // Check tests if a version satisfies the constraints.
 func (c Constraint) Check(versionFetcher VersionFetcher) bool {
 	return c.Constraint.Check(versionFetcher.Version())
 }
+
+// Excludes returns the first exclusion that the version satisfies, or nil if the version is not excluded.
+func (c Constraint) Excludes(versionFetcher VersionFetcher) *semver.Constraints {
+	return c.Exclusions.Excludes(versionFetcher.Version())
+}
+
+// allows reports whether the version satisfies the constraint and is not excluded
+func (c Constraint) allows(versionFetcher VersionFetcher) bool {
+	return c.Check(versionFetcher) && c.Excludes(versionFetcher) == nil
+}
+
+// LimitPatches will return the newest versions allowed by Patches, according to the PatchWindow.
+// The result is sorted from oldest to newest, and the input is not modified.
+func (c Constraint) LimitPatches(versions []VersionFetcher) []VersionFetcher {
+	sorted := make([]VersionFetcher, len(versions))
+	copy(sorted, versions)
+	ascending := func(i, j int) bool {
+		return sorted[i].Version().LessThan(sorted[j].Version())
+	}
+	if !sort.SliceIsSorted(sorted, ascending) {
+		sort.Slice(sorted, ascending)
+	}
+
+	if c.Window != PatchWindowMinor {
+		if c.Patches < len(sorted) {
+			return sorted[len(sorted)-c.Patches:]
+		}
+		return sorted
+	}
+
+	remaining := make(map[string]int)
+	var limited []VersionFetcher
+	for i := len(sorted) - 1; i >= 0; i-- {
+		minor := minorLine(sorted[i])
+		if _, ok := remaining[minor]; !ok {
+			remaining[minor] = c.Patches
+		}
+
+		if remaining[minor] > 0 {
+			remaining[minor]--
+			limited = append(limited, sorted[i])
+		}
+	}
+
+	return reversed(limited)
+}
+
+// MissingFromWindow will return those versions retained by LimitPatches that are not found in existingVersions.
+// This allows a version skipped in a previous run to be returned, even when newer versions already exist.
+// With PatchWindowMinor, the major.minor lines older than those of existingVersions are not considered.
+func (c Constraint) MissingFromWindow(versions, existingVersions []VersionFetcher) []VersionFetcher {
+	existing := make(map[string]bool)
+	for _, version := range existingVersions {
+		existing[versionKey(version)] = true
+	}
+
+	if c.Window == PatchWindowMinor {
+		versions = fromOldestMinorLine(versions, existingVersions)
+	}
+
+	return collections.FilterFunc(c.LimitPatches(versions), func(version VersionFetcher) bool {
+		return !existing[versionKey(version)]
+	})
+}
+
+// matching will return those versions that satisfy the constraint and are not excluded, logging each exclusion
+func (c Constraint) matching(id string, versions []VersionFetcher) []VersionFetcher {
+	var matching []VersionFetcher
+	for _, version := range versions {
+		if !c.Check(version) {
+			continue
+		}
+
+		if exclusion := c.Excludes(version); exclusion != nil {
+			fmt.Printf("Excluding %s %s for constraint %s, matched exclusion '%s'\n",
+				id,
+				version.Version().String(),
+				c.Constraint.String(),
+				exclusion.String())
+			continue
+		}
+
+		matching = append(matching, version)
+	}
+	return matching
+}
+
+// newerThan will return those ascending versions that are newer than every existing version,
+// or newer than every existing version of the same major.minor line when using PatchWindowMinor.
+// In the latter case, lines older than the oldest line of the existing versions are left out.
+func (c Constraint) newerThan(versions, existingVersions []VersionFetcher) []VersionFetcher {
+	if c.Window != PatchWindowMinor {
+		return newerThan(versions, newestVersion(existingVersions))
+	}
+
+	versions = fromOldestMinorLine(versions, existingVersions)
+
+	newestPerMinor := make(map[string]*semver.Version)
+	for _, existing := range existingVersions {
+		minor := minorLine(existing)
+		if newest, ok := newestPerMinor[minor]; !ok || existing.Version().GreaterThan(newest) {
+			newestPerMinor[minor] = existing.Version()
+		}
+	}
+
+	return collections.FilterFunc(versions, func(version VersionFetcher) bool {
+		newest, ok := newestPerMinor[minorLine(version)]
+		return !ok || version.Version().GreaterThan(newest)
+	})
+}
+
+// PatchesDescription will describe the patch limit for use in logs
+func (c Constraint) PatchesDescription() string {
+	if c.Window == PatchWindowMinor {
+		return fmt.Sprintf("%d patches per minor", c.Patches)
+	}
+	return fmt.Sprintf("%d patches", c.Patches)
+}
+
+// fromOldestMinorLine will return those versions whose major.minor line is at or above the oldest line of
+// existingVersions, or all versions when there are no existing versions
+func fromOldestMinorLine(versions, existingVersions []VersionFetcher) []VersionFetcher {
+	oldest := VersionFetcherArray(existingVersions).Sorted()
+	if len(oldest) < 1 {
+		return versions
+	}
+	major, minor := oldest[0].Version().Major(), oldest[0].Version().Minor()
+
+	return collections.FilterFunc(versions, func(version VersionFetcher) bool {
+		return version.Version().Major() > major ||
+			(version.Version().Major() == major && version.Version().Minor() >= minor)
+	})
+}
+
+func minorLine(version VersionFetcher) string {
+	return fmt.Sprintf("%d.%d", version.Version().Major(), version.Version().Minor())
+}
+
+// versionKey identifies a version ignoring build metadata, consistent with semver.Version.Equal
+func versionKey(version VersionFetcher) string {
+	withoutMetadata, _ := version.Version().SetMetadata("")
+	return withoutMetadata.String()
+}
@@ -11,10 +11,26 @@ import (
 type Dependency struct {
 	cargo.ConfigMetadataDependency
 	SemverVersion *semver.Version `json:"-"`
-	Target        string          `json:"target,omitempty"`
+	Target        Target          `json:"target,omitzero"`
 }
 
+// NewDependency will create a Dependency for the given target, parsed with ParseTarget.
+// The stacks of the target are taken from configMetadataDependency.
 func NewDependency(configMetadataDependency cargo.ConfigMetadataDependency, target string) (Dependency, error) {
+	parsedTarget := ParseTarget(target)
+	parsedTarget.Stacks = configMetadataDependency.Stacks
+
+	return NewDependencyWithTarget(configMetadataDependency, parsedTarget)
+}
+
+// NewDependencyFromConfig will create a Dependency whose Target is filled from the os, arch, distros and stacks
+// of the buildpack.toml entry, see NewTargetFromConfigDependency
+func NewDependencyFromConfig(configMetadataDependency cargo.ConfigMetadataDependency) (Dependency, error) {
+	return NewDependencyWithTarget(configMetadataDependency, NewTargetFromConfigDependency(configMetadataDependency))
+}
+
+// NewDependencyWithTarget will create a Dependency for the given structured target
+func NewDependencyWithTarget(configMetadataDependency cargo.ConfigMetadataDependency, target Target) (Dependency, error) {
 	if semverVersion, err := semver.NewVersion(configMetadataDependency.Version); err != nil {
 		return Dependency{}, err
 	} else {
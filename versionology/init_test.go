@@ -11,5 +11,10 @@ func TestUnitFuncs(t *testing.T) {
 	suite := spec.New("versionology", spec.Report(report.Terminal{}))
 	suite("Versionology", testVersionology)
 	suite("VersionFetcher", testVersionFetcher)
+	suite("Lint", testLint)
+	suite("Target", testTarget)
+	suite("Metadata", testMetadata)
+	suite("ReleaseDate", testReleaseDate)
+	suite("VersionExtractor", testVersionExtractor)
 	suite.Run(t)
 }
@@ -0,0 +1,113 @@
+package versionology
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Severity describes how serious a LintFinding is
+type Severity string
+
+const (
+	// SeverityError is used for problems that will cause incorrect behavior
+	SeverityError Severity = "error"
+
+	// SeverityWarning is used for problems that are likely to be mistakes
+	SeverityWarning Severity = "warning"
+)
+
+// LintFinding is a single problem reported by a lint check
+type LintFinding struct {
+	Severity Severity `json:"severity"`
+	ID       string   `json:"id"`
+	Message  string   `json:"message"`
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.ID, f.Message)
+}
+
+// HasErrors reports whether any of the findings has SeverityError
+func HasErrors(findings []LintFinding) bool {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// LintConstraints will report the following problems with the constraints for a dependency id:
+// - constraints that no existing dependency satisfies
+// - constraints that match no upstream versions, or only excluded ones (only checked when upstreamVersions is not nil)
+// - pairs of constraints that both allow the same existing or upstream version, i.e. satisfied and not excluded
+// - existing dependencies that do not satisfy any constraint
+//
+// Overlap is determined from the known versions because semver constraints cannot be intersected in general.
+func LintConstraints(id string, constraints []Constraint, existingVersions, upstreamVersions VersionFetcherArray) []LintFinding {
+	var findings []LintFinding
+
+	for _, constraint := range constraints {
+		if len(existingVersions.FilterByConstraint(constraint.Constraint)) < 1 {
+			findings = append(findings, LintFinding{
+				Severity: SeverityWarning,
+				ID:       id,
+				Message:  fmt.Sprintf("constraint %s is not satisfied by any existing dependency", constraint.Constraint.String()),
+			})
+		}
+
+		if upstreamVersions != nil {
+			matching := upstreamVersions.FilterByConstraint(constraint.Constraint)
+			if len(matching) < 1 {
+				findings = append(findings, LintFinding{
+					Severity: SeverityWarning,
+					ID:       id,
+					Message:  fmt.Sprintf("constraint %s does not match any upstream version", constraint.Constraint.String()),
+				})
+			} else if !slices.ContainsFunc(matching, constraint.allows) {
+				findings = append(findings, LintFinding{
+					Severity: SeverityWarning,
+					ID:       id,
+					Message:  fmt.Sprintf("constraint %s only matches excluded upstream versions", constraint.Constraint.String()),
+				})
+			}
+		}
+	}
+
+	knownVersions := append(append(NewVersionFetcherArray(), existingVersions...), upstreamVersions...).Dedup().Sorted()
+	for i := range constraints {
+		for j := i + 1; j < len(constraints); j++ {
+			for _, version := range knownVersions {
+				if constraints[i].allows(version) && constraints[j].allows(version) {
+					findings = append(findings, LintFinding{
+						Severity: SeverityWarning,
+						ID:       id,
+						Message: fmt.Sprintf("constraints %s and %s overlap, both are satisfied by %s",
+							constraints[i].Constraint.String(),
+							constraints[j].Constraint.String(),
+							version.Version().String()),
+					})
+					break
+				}
+			}
+		}
+	}
+
+	if len(constraints) > 0 {
+	ExistingVersionsLoop:
+		for _, version := range existingVersions.Dedup().Sorted() {
+			for _, constraint := range constraints {
+				if constraint.Check(version) {
+					continue ExistingVersionsLoop
+				}
+			}
+			findings = append(findings, LintFinding{
+				Severity: SeverityWarning,
+				ID:       id,
+				Message:  fmt.Sprintf("dependency %s does not satisfy any constraint", version.Version().String()),
+			})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,116 @@
+package versionology_test
+
+import (
+	"testing"
+
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testLint(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	context("LintConstraints", func() {
+		var constraints []versionology.Constraint
+
+		it.Before(func() {
+			constraints = nil
+			for _, c := range []string{"1.*", ">=1.5.0 <2.0.0", "3.*"} {
+				constraint, err := versionology.NewConstraint(cargo.ConfigMetadataDependencyConstraint{Constraint: c})
+				Expect(err).NotTo(HaveOccurred())
+				constraints = append(constraints, constraint)
+			}
+		})
+
+		it("will report problems with the constraints", func() {
+			existingVersions, err := versionology.NewSimpleVersionFetcherArray("1.5.1", "2.0.0")
+			Expect(err).NotTo(HaveOccurred())
+
+			upstreamVersions, err := versionology.NewSimpleVersionFetcherArray("1.5.1", "1.6.0", "2.0.0")
+			Expect(err).NotTo(HaveOccurred())
+
+			findings := versionology.LintConstraints("dep", constraints, existingVersions, upstreamVersions)
+			Expect(findings).To(ConsistOf(
+				versionology.LintFinding{
+					Severity: versionology.SeverityWarning,
+					ID:       "dep",
+					Message:  "constraint 3.* is not satisfied by any existing dependency",
+				},
+				versionology.LintFinding{
+					Severity: versionology.SeverityWarning,
+					ID:       "dep",
+					Message:  "constraint 3.* does not match any upstream version",
+				},
+				versionology.LintFinding{
+					Severity: versionology.SeverityWarning,
+					ID:       "dep",
+					Message:  "constraints 1.* and >=1.5.0 <2.0.0 overlap, both are satisfied by 1.5.1",
+				},
+				versionology.LintFinding{
+					Severity: versionology.SeverityWarning,
+					ID:       "dep",
+					Message:  "dependency 2.0.0 does not satisfy any constraint",
+				},
+			))
+			Expect(versionology.HasErrors(findings)).To(BeFalse())
+		})
+
+		it("will take the exclusions of the constraints into account", func() {
+			excluded := make([]versionology.Constraint, 0, len(constraints))
+			for _, c := range []struct{ constraint, exclusion string }{
+				{"1.*", ">=1.5.0"},
+				{">=1.5.0 <2.0.0", "1.5.1"},
+				{"3.*", "3.0.0"},
+			} {
+				constraint, err := versionology.NewConstraintWithExclusions(cargo.ConfigMetadataDependencyConstraint{Constraint: c.constraint}, c.exclusion)
+				Expect(err).NotTo(HaveOccurred())
+				excluded = append(excluded, constraint)
+			}
+
+			existingVersions, err := versionology.NewSimpleVersionFetcherArray("1.0.0", "1.6.0", "3.0.0")
+			Expect(err).NotTo(HaveOccurred())
+
+			upstreamVersions, err := versionology.NewSimpleVersionFetcherArray("1.0.0", "1.5.1", "1.6.0", "3.0.0")
+			Expect(err).NotTo(HaveOccurred())
+
+			findings := versionology.LintConstraints("dep", excluded, existingVersions, upstreamVersions)
+			Expect(findings).To(ConsistOf(versionology.LintFinding{
+				Severity: versionology.SeverityWarning,
+				ID:       "dep",
+				Message:  "constraint 3.* only matches excluded upstream versions",
+			}))
+		})
+
+		it("will not check upstream versions when they are not provided", func() {
+			existingVersions, err := versionology.NewSimpleVersionFetcherArray("1.0.0", "3.0.0")
+			Expect(err).NotTo(HaveOccurred())
+
+			findings := versionology.LintConstraints("dep", constraints[:1], existingVersions, nil)
+			Expect(findings).To(ConsistOf(versionology.LintFinding{
+				Severity: versionology.SeverityWarning,
+				ID:       "dep",
+				Message:  "dependency 3.0.0 does not satisfy any constraint",
+			}))
+		})
+
+		it("will not report dependencies outside of constraints when there are no constraints", func() {
+			existingVersions, err := versionology.NewSimpleVersionFetcherArray("1.0.0")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(versionology.LintConstraints("dep", nil, existingVersions, nil)).To(BeEmpty())
+		})
+	})
+
+	context("LintFinding", func() {
+		it("will format the finding", func() {
+			Expect(versionology.LintFinding{
+				Severity: versionology.SeverityError,
+				ID:       "dep",
+				Message:  "something is wrong",
+			}.String()).To(Equal("error: dep: something is wrong"))
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package versionology
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// UnmarshalJSON will restore SemverVersion from the embedded Version field,
+// so that a Dependency read from metadata.json can be used as a VersionFetcher.
+func (d *Dependency) UnmarshalJSON(data []byte) error {
+	// dependencyJSON has the same fields as Dependency but none of its methods, avoiding recursion
+	type dependencyJSON Dependency
+
+	var dependency dependencyJSON
+	if err := json.Unmarshal(data, &dependency); err != nil {
+		return err
+	}
+
+	semverVersion, err := semver.NewVersion(dependency.ConfigMetadataDependency.Version)
+	if err != nil {
+		return fmt.Errorf("invalid version '%s': %w", dependency.ConfigMetadataDependency.Version, err)
+	}
+
+	dependency.SemverVersion = semverVersion
+	dependency.Target.Stacks = dependency.Stacks
+	*d = Dependency(dependency)
+	return nil
+}
+
+// metadataEnvelope is a metadata.json written as an object instead of a bare array
+type metadataEnvelope struct {
+	Dependencies []json.RawMessage `json:"dependencies"`
+}
+
+// ParseMetadata will parse the contents of a metadata.json into Dependencies.
+// The current format is a JSON array of dependencies. An object with a "dependencies" array is also accepted,
+// and any other fields of that object are ignored, so that the envelope can be extended without breaking readers.
+//
+// Each dependency must have an id and a valid semver version.
+func ParseMetadata(content []byte) ([]Dependency, error) {
+	var entries []json.RawMessage
+
+	switch trimmed := bytes.TrimSpace(content); {
+	case len(trimmed) > 0 && trimmed[0] == '[':
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, fmt.Errorf("unable to parse metadata: %w", err)
+		}
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		var envelope metadataEnvelope
+		if err := json.Unmarshal(trimmed, &envelope); err != nil {
+			return nil, fmt.Errorf("unable to parse metadata: %w", err)
+		}
+		if envelope.Dependencies == nil {
+			return nil, errors.New("unable to parse metadata: missing \"dependencies\"")
+		}
+		entries = envelope.Dependencies
+	default:
+		return nil, errors.New("unable to parse metadata: expected a JSON array or object")
+	}
+
+	dependencies := make([]Dependency, 0, len(entries))
+	for i, entry := range entries {
+		var dependency Dependency
+		if err := json.Unmarshal(entry, &dependency); err != nil {
+			return nil, fmt.Errorf("unable to parse metadata entry %d: %w", i, err)
+		}
+
+		if dependency.ID == "" {
+			return nil, fmt.Errorf("unable to parse metadata entry %d: missing id", i)
+		}
+
+		dependencies = append(dependencies, dependency)
+	}
+
+	return dependencies, nil
+}
+
+// ReadMetadataFile will read a metadata.json, such as one written by retrieve.NewMetadata, into Dependencies.
+// See ParseMetadata for the accepted formats.
+func ReadMetadataFile(path string) ([]Dependency, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read metadata file: %w", err)
+	}
+
+	return ParseMetadata(content)
+}
@@ -0,0 +1,88 @@
+package versionology_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testMetadata(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	context("ParseMetadata", func() {
+		it("will round trip a marshalled array of dependencies", func() {
+			dependencies, err := versionology.NewDependencyArray(cargo.ConfigMetadataDependency{
+				ID:      "dep",
+				Stacks:  []string{"stack"},
+				Version: "1.2.3",
+			}, "linux-64", "jammy")
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := json.Marshal(dependencies)
+			Expect(err).NotTo(HaveOccurred())
+
+			parsed, err := versionology.ParseMetadata(content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(Equal(dependencies))
+			Expect(parsed[0].Version().String()).To(Equal("1.2.3"))
+		})
+
+		it("will accept an envelope object", func() {
+			parsed, err := versionology.ParseMetadata([]byte(`{
+				"schema": 2,
+				"dependencies": [{"id":"dep","version":"v1.2","target":"jammy"}]
+			}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsed).To(HaveLen(1))
+			Expect(parsed[0].Version().String()).To(Equal("1.2.0"))
+			Expect(parsed[0].Target.String()).To(Equal("jammy"))
+		})
+
+		context("failure cases", func() {
+			it("will return an error for an invalid version", func() {
+				_, err := versionology.ParseMetadata([]byte(`[{"id":"dep","version":"1.0.0"},{"id":"dep","version":"not valid"}]`))
+				Expect(err).To(MatchError(ContainSubstring("unable to parse metadata entry 1: invalid version 'not valid'")))
+			})
+
+			it("will return an error for a missing id", func() {
+				_, err := versionology.ParseMetadata([]byte(`[{"version":"1.0.0"}]`))
+				Expect(err).To(MatchError("unable to parse metadata entry 0: missing id"))
+			})
+
+			it("will return an error for an envelope without dependencies", func() {
+				_, err := versionology.ParseMetadata([]byte(`{"schema": 2}`))
+				Expect(err).To(MatchError(`unable to parse metadata: missing "dependencies"`))
+			})
+
+			it("will return an error for other JSON", func() {
+				_, err := versionology.ParseMetadata([]byte(`"hi"`))
+				Expect(err).To(MatchError("unable to parse metadata: expected a JSON array or object"))
+			})
+		})
+	})
+
+	context("ReadMetadataFile", func() {
+		it("will read the file", func() {
+			path := filepath.Join(t.TempDir(), "metadata.json")
+			Expect(os.WriteFile(path, []byte(`[{"id":"dep","version":"1.2.3"}]`), 0600)).To(Succeed())
+
+			dependencies, err := versionology.ReadMetadataFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versionology.Versions(dependencies)).To(Equal([]string{"1.2.3"}))
+		})
+
+		context("failure cases", func() {
+			it("will return an error when the file does not exist", func() {
+				_, err := versionology.ReadMetadataFile("/does/not/exist")
+				Expect(err).To(MatchError(os.ErrNotExist))
+			})
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package versionology
+
+import (
+	"time"
+)
+
+// ReleaseDateFetcher can optionally be implemented by a VersionFetcher that knows when its version was published
+// upstream. A zero time.Time means that the release date is not known.
+type ReleaseDateFetcher interface {
+	ReleaseDate() time.Time
+}
+
+// FilterByMinimumAge will split the versions into those released at least minimumAge before now, and those that are
+// still cooling down. Versions that do not implement ReleaseDateFetcher, or whose release date is unknown,
+// are never held back. The input is not modified.
+func FilterByMinimumAge(versions VersionFetcherArray, minimumAge time.Duration, now time.Time) (ready, coolingDown VersionFetcherArray) {
+	ready = NewVersionFetcherArray()
+	coolingDown = NewVersionFetcherArray()
+
+	cutoff := now.Add(-minimumAge)
+	for _, version := range versions {
+		if releaseDateFetcher, ok := version.(ReleaseDateFetcher); ok {
+			if releaseDate := releaseDateFetcher.ReleaseDate(); !releaseDate.IsZero() && releaseDate.After(cutoff) {
+				coolingDown = append(coolingDown, version)
+				continue
+			}
+		}
+		ready = append(ready, version)
+	}
+
+	return ready, coolingDown
+}
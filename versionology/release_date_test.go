@@ -0,0 +1,46 @@
+package versionology_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+type datedVersionFetcher struct {
+	version     *semver.Version
+	releaseDate time.Time
+}
+
+func (d datedVersionFetcher) Version() *semver.Version {
+	return d.version
+}
+
+func (d datedVersionFetcher) ReleaseDate() time.Time {
+	return d.releaseDate
+}
+
+func testReleaseDate(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	context("FilterByMinimumAge", func() {
+		it("will hold back versions released too recently", func() {
+			now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+			versions := versionology.VersionFetcherArray{
+				datedVersionFetcher{version: semver.MustParse("1.0.0"), releaseDate: now.AddDate(0, 0, -30)},
+				datedVersionFetcher{version: semver.MustParse("1.1.0"), releaseDate: now.AddDate(0, 0, -7)},
+				datedVersionFetcher{version: semver.MustParse("1.2.0"), releaseDate: now.AddDate(0, 0, -1)},
+				datedVersionFetcher{version: semver.MustParse("1.3.0")},
+				versionology.NewSimpleVersionFetcher(semver.MustParse("1.4.0")),
+			}
+
+			ready, coolingDown := versionology.FilterByMinimumAge(versions, 7*24*time.Hour, now)
+			Expect(ready.GetVersionStrings()).To(Equal([]string{"1.0.0", "1.1.0", "1.3.0", "1.4.0"}))
+			Expect(coolingDown.GetVersionStrings()).To(Equal([]string{"1.2.0"}))
+		})
+	})
+}
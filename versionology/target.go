@@ -0,0 +1,164 @@
+package versionology
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+)
+
+// Target describes the platform that a Dependency was built for.
+// It is written to metadata.json as a single string (see Target.String) so that the metadata.json format is unchanged.
+type Target struct {
+	OS            string
+	Arch          string
+	Variant       string
+	DistroName    string
+	DistroVersion string
+	Stacks        []string
+
+	// parsed is the free-form string this Target was parsed from, and the fields it was parsed into,
+	// so that an unmodified Target is written back out unchanged, even when that string is empty
+	parsed       string
+	parsedFields targetFields
+}
+
+// targetFields are the fields of a Target that determine its string form
+type targetFields struct {
+	OS, Arch, Variant, DistroName, DistroVersion string
+}
+
+func (t Target) fields() targetFields {
+	return targetFields{OS: t.OS, Arch: t.Arch, Variant: t.Variant, DistroName: t.DistroName, DistroVersion: t.DistroVersion}
+}
+
+// distroCodenames maps the stack names historically used as targets to their distro
+var distroCodenames = map[string]cargo.ConfigDistro{
+	"bionic": {Name: "ubuntu", Version: "18.04"},
+	"focal":  {Name: "ubuntu", Version: "20.04"},
+	"jammy":  {Name: "ubuntu", Version: "22.04"},
+	"noble":  {Name: "ubuntu", Version: "24.04"},
+}
+
+// archAliases maps the architecture names found in targets to their GOARCH equivalent
+var archAliases = map[string]string{
+	"64":      "amd64",
+	"x64":     "amd64",
+	"x86_64":  "amd64",
+	"aarch64": "arm64",
+}
+
+var targetPattern = regexp.MustCompile(`^(linux|windows|darwin)-([^-]+)(?:-(v\d+))?(?:-([a-z]+)-(\d[^-]*))?$`)
+
+// ParseTarget will translate a free-form target string (e.g. "linux-64", "jammy" or "linux-arm64-v8-ubuntu-22.04")
+// into a Target. Strings that are not recognized are kept as-is. Target.String will return the input for as long as
+// the fields of the Target are not modified.
+func ParseTarget(target string) Target {
+	parsed := Target{}
+
+	if distro, ok := distroCodenames[target]; ok {
+		parsed.OS = "linux"
+		parsed.DistroName = distro.Name
+		parsed.DistroVersion = distro.Version
+		parsed.parsed = target
+		parsed.parsedFields = parsed.fields()
+		return parsed
+	}
+
+	if matches := targetPattern.FindStringSubmatch(target); matches != nil {
+		parsed.OS = matches[1]
+		parsed.Arch = matches[2]
+		if alias, ok := archAliases[parsed.Arch]; ok {
+			parsed.Arch = alias
+		}
+		parsed.Variant = matches[3]
+		parsed.DistroName = matches[4]
+		parsed.DistroVersion = matches[5]
+	}
+
+	parsed.parsed = target
+	parsed.parsedFields = parsed.fields()
+	return parsed
+}
+
+// NewTargetFromConfigTarget will translate a `[[targets]]` entry of a buildpack.toml into a Target
+func NewTargetFromConfigTarget(target cargo.ConfigTarget) Target {
+	return Target{
+		OS:   target.OS,
+		Arch: target.Arch,
+	}
+}
+
+// NewTargetFromConfigDependency will fill a Target from the os, arch, distros and stacks of a
+// `[[metadata.dependencies]]` entry of a buildpack.toml. A Target holds a single distro, so the distro is only set
+// when the entry lists exactly one. The Target is written as an empty string until its fields are modified,
+// so that metadata.json does not change for dependencies that had no target.
+func NewTargetFromConfigDependency(dependency cargo.ConfigMetadataDependency) Target {
+	target := Target{
+		OS:     dependency.OS,
+		Arch:   dependency.Arch,
+		Stacks: dependency.Stacks,
+	}
+	if alias, ok := archAliases[target.Arch]; ok {
+		target.Arch = alias
+	}
+	if len(dependency.Distros) == 1 {
+		target.DistroName = dependency.Distros[0].Name
+		target.DistroVersion = dependency.Distros[0].Version
+	}
+
+	target.parsedFields = target.fields()
+	return target
+}
+
+// ConfigTarget will translate the Target into a `[[targets]]` entry of a buildpack.toml
+func (t Target) ConfigTarget() cargo.ConfigTarget {
+	return cargo.ConfigTarget{
+		OS:   t.OS,
+		Arch: t.Arch,
+	}
+}
+
+// ConfigDistros will translate the distro of the Target into the `distros` of a `[[metadata.dependencies]]` entry
+func (t Target) ConfigDistros() []cargo.ConfigDistro {
+	if t.DistroName == "" {
+		return nil
+	}
+	return []cargo.ConfigDistro{{Name: t.DistroName, Version: t.DistroVersion}}
+}
+
+// String will return the string this Target was parsed from, if none of its fields were modified since.
+// Otherwise it returns `<os>-<arch>[-<variant>][-<distro name>-<distro version>]`, omitting empty fields.
+func (t Target) String() string {
+	if t.fields() == t.parsedFields {
+		return t.parsed
+	}
+
+	var parts []string
+	for _, part := range []string{t.OS, t.Arch, t.Variant, t.DistroName, t.DistroVersion} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// IsZero reports whether the Target would be written as an empty string
+func (t Target) IsZero() bool {
+	return t.String() == ""
+}
+
+func (t Target) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *Target) UnmarshalJSON(data []byte) error {
+	var target string
+	if err := json.Unmarshal(data, &target); err != nil {
+		return err
+	}
+
+	*t = ParseTarget(target)
+	return nil
+}
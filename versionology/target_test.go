@@ -0,0 +1,152 @@
+package versionology_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testTarget(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	context("ParseTarget", func() {
+		it("will parse an os and arch, normalizing the arch", func() {
+			target := versionology.ParseTarget("linux-64")
+			Expect(target.OS).To(Equal("linux"))
+			Expect(target.Arch).To(Equal("amd64"))
+			Expect(target.String()).To(Equal("linux-64"))
+		})
+
+		it("will parse a variant and distro", func() {
+			target := versionology.ParseTarget("linux-arm64-v8-ubuntu-22.04")
+			Expect(target.OS).To(Equal("linux"))
+			Expect(target.Arch).To(Equal("arm64"))
+			Expect(target.Variant).To(Equal("v8"))
+			Expect(target.DistroName).To(Equal("ubuntu"))
+			Expect(target.DistroVersion).To(Equal("22.04"))
+		})
+
+		it("will parse a stack codename", func() {
+			target := versionology.ParseTarget("jammy")
+			Expect(target.OS).To(Equal("linux"))
+			Expect(target.DistroName).To(Equal("ubuntu"))
+			Expect(target.DistroVersion).To(Equal("22.04"))
+			Expect(target.ConfigDistros()).To(Equal([]cargo.ConfigDistro{{Name: "ubuntu", Version: "22.04"}}))
+			Expect(target.String()).To(Equal("jammy"))
+		})
+
+		it("will keep unrecognized targets as-is", func() {
+			target := versionology.ParseTarget("target1")
+			Expect(target.OS).To(BeEmpty())
+			Expect(target.String()).To(Equal("target1"))
+		})
+	})
+
+	context("NewTargetFromConfigDependency", func() {
+		it("will fill the fields but be written as an empty string until modified", func() {
+			target := versionology.NewTargetFromConfigDependency(cargo.ConfigMetadataDependency{
+				OS:      "linux",
+				Arch:    "x86_64",
+				Distros: []cargo.ConfigDistro{{Name: "ubuntu", Version: "22.04"}},
+				Stacks:  []string{"io.buildpacks.stacks.jammy"},
+			})
+			Expect(target.OS).To(Equal("linux"))
+			Expect(target.Arch).To(Equal("amd64"))
+			Expect(target.DistroName).To(Equal("ubuntu"))
+			Expect(target.DistroVersion).To(Equal("22.04"))
+			Expect(target.Stacks).To(Equal([]string{"io.buildpacks.stacks.jammy"}))
+			Expect(target.String()).To(BeEmpty())
+			Expect(target.IsZero()).To(BeTrue())
+
+			target.Arch = "arm64"
+			Expect(target.String()).To(Equal("linux-arm64-ubuntu-22.04"))
+		})
+
+		it("will only set the distro when there is exactly one", func() {
+			target := versionology.NewTargetFromConfigDependency(cargo.ConfigMetadataDependency{
+				OS: "linux",
+				Distros: []cargo.ConfigDistro{
+					{Name: "ubuntu", Version: "22.04"},
+					{Name: "ubuntu", Version: "24.04"},
+				},
+			})
+			Expect(target.DistroName).To(BeEmpty())
+			Expect(target.DistroVersion).To(BeEmpty())
+		})
+	})
+
+	context("String", func() {
+		it("will compose the fields when not parsed from a string", func() {
+			Expect(versionology.Target{OS: "linux", Arch: "amd64"}.String()).To(Equal("linux-amd64"))
+			Expect(versionology.Target{
+				OS:            "linux",
+				Arch:          "arm64",
+				DistroName:    "ubuntu",
+				DistroVersion: "24.04",
+			}.String()).To(Equal("linux-arm64-ubuntu-24.04"))
+		})
+
+		it("will compose the fields when a parsed target was modified", func() {
+			target := versionology.ParseTarget("linux-64")
+			target.Arch = "arm64"
+			Expect(target.String()).To(Equal("linux-arm64"))
+
+			content, err := json.Marshal(target)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal(`"linux-arm64"`))
+
+			codename := versionology.ParseTarget("jammy")
+			codename.DistroVersion = "24.04"
+			Expect(codename.String()).To(Equal("linux-ubuntu-24.04"))
+
+			unrecognized := versionology.ParseTarget("target1")
+			unrecognized.OS = "linux"
+			Expect(unrecognized.String()).To(Equal("linux"))
+		})
+	})
+
+	context("ConfigTarget", func() {
+		it("will convert to and from cargo targets", func() {
+			target := versionology.NewTargetFromConfigTarget(cargo.ConfigTarget{OS: "linux", Arch: "arm64"})
+			Expect(target.String()).To(Equal("linux-arm64"))
+			Expect(target.ConfigTarget()).To(Equal(cargo.ConfigTarget{OS: "linux", Arch: "arm64"}))
+		})
+	})
+
+	context("JSON", func() {
+		it("will marshal a Dependency with the target as a string", func() {
+			dependency, err := versionology.NewDependency(cargo.ConfigMetadataDependency{
+				ID:      "dep",
+				Stacks:  []string{"stack"},
+				Version: "1.2.3",
+			}, "linux-64")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dependency.Target.Stacks).To(Equal([]string{"stack"}))
+
+			content, err := json.Marshal(dependency)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(MatchJSON(`{"id":"dep","stacks":["stack"],"version":"1.2.3","target":"linux-64"}`))
+
+			var target versionology.Target
+			Expect(json.Unmarshal([]byte(`"linux-64"`), &target)).To(Succeed())
+			Expect(target).To(Equal(versionology.ParseTarget("linux-64")))
+		})
+
+		it("will omit an empty target", func() {
+			dependency, err := versionology.NewDependency(cargo.ConfigMetadataDependency{
+				ID:      "dep",
+				Version: "1.2.3",
+			}, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			content, err := json.Marshal(dependency)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(MatchJSON(`{"id":"dep","version":"1.2.3"}`))
+		})
+	})
+}
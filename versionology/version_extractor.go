@@ -0,0 +1,53 @@
+package versionology
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// VersionExtractor will translate a release name or tag name into a semver version,
+// returning an error when the value does not contain a version
+type VersionExtractor func(value string) (*semver.Version, error)
+
+// DefaultVersionExtractor will parse the value as a semver version after trimming whitespace.
+// A leading 'v' is allowed.
+func DefaultVersionExtractor(value string) (*semver.Version, error) {
+	return semver.NewVersion(strings.TrimSpace(value))
+}
+
+// NewRegexVersionExtractor will return a VersionExtractor that parses the named capture group `version`
+// of the pattern as a semver version. For example:
+//
+//	release-(?P<version>\d+\.\d+\.\d+)  matches release-1.2.3
+//	^go(?P<version>\d+\.\d+(\.\d+)?)$   matches go1.22.1
+//	^jdk-(?P<version>.+)$               matches jdk-17.0.2+8
+func NewRegexVersionExtractor(pattern string) (VersionExtractor, error) {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version pattern: %w", err)
+	}
+
+	index := regex.SubexpIndex("version")
+	if index < 0 {
+		return nil, fmt.Errorf("invalid version pattern %s: missing a named capture group 'version'", pattern)
+	}
+
+	return func(value string) (*semver.Version, error) {
+		matches := regex.FindStringSubmatch(strings.TrimSpace(value))
+		if matches == nil {
+			return nil, fmt.Errorf("'%s' does not match %s", value, pattern)
+		}
+		return semver.NewVersion(matches[index])
+	}, nil
+}
+
+// UnderscoresToDots will return a VersionExtractor that replaces underscores with dots in the value
+// before calling extractor, e.g. so that curl-7_78_0 can be parsed as 7.78.0
+func UnderscoresToDots(extractor VersionExtractor) VersionExtractor {
+	return func(value string) (*semver.Version, error) {
+		return extractor(strings.ReplaceAll(value, "_", "."))
+	}
+}
@@ -0,0 +1,65 @@
+package versionology_test
+
+import (
+	"testing"
+
+	"github.com/paketo-buildpacks/libdependency/versionology"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testVersionExtractor(t *testing.T, context spec.G, it spec.S) {
+	Expect := NewWithT(t).Expect
+
+	context("NewRegexVersionExtractor", func() {
+		it("will parse the version capture group", func() {
+			for pattern, examples := range map[string]map[string]string{
+				`^release-(?P<version>.+)$`: {"release-1.2.3": "1.2.3"},
+				`^go(?P<version>.+)$`:       {"go1.22.1": "1.22.1", "go1.22": "1.22.0"},
+				`^jdk-(?P<version>.+)$`:     {"jdk-17.0.2+8": "17.0.2+8"},
+			} {
+				extractor, err := versionology.NewRegexVersionExtractor(pattern)
+				Expect(err).NotTo(HaveOccurred())
+
+				for value, expected := range examples {
+					version, err := extractor(value)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(version.String()).To(Equal(expected))
+				}
+			}
+		})
+
+		context("failure cases", func() {
+			it("will return an error when the pattern has no version group", func() {
+				_, err := versionology.NewRegexVersionExtractor(`^release-(.+)$`)
+				Expect(err).To(MatchError("invalid version pattern ^release-(.+)$: missing a named capture group 'version'"))
+			})
+
+			it("will return an error when the pattern is invalid", func() {
+				_, err := versionology.NewRegexVersionExtractor(`(`)
+				Expect(err).To(MatchError(ContainSubstring("invalid version pattern")))
+			})
+
+			it("will return an error when the value does not match", func() {
+				extractor, err := versionology.NewRegexVersionExtractor(`^release-(?P<version>.+)$`)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = extractor("v1.2.3")
+				Expect(err).To(MatchError("'v1.2.3' does not match ^release-(?P<version>.+)$"))
+			})
+		})
+	})
+
+	context("UnderscoresToDots", func() {
+		it("will replace underscores before extracting", func() {
+			extractor, err := versionology.NewRegexVersionExtractor(`^curl-(?P<version>\d+\.\d+\.\d+)$`)
+			Expect(err).NotTo(HaveOccurred())
+
+			version, err := versionology.UnderscoresToDots(extractor)("curl-7_78_0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version.String()).To(Equal("7.78.0"))
+		})
+	})
+
+}
@@ -1,6 +1,7 @@
 package versionology
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/Masterminds/semver/v3"
@@ -32,15 +33,112 @@ func (versions VersionFetcherArray) GetVersionStrings() []string {
 	return VersionFetcherToString(versions)
 }
 
+// GetNewestVersion will return the newest version as a string, or "" if there are no versions.
+// The receiver is not modified.
 func (versions VersionFetcherArray) GetNewestVersion() string {
-	if len(versions) < 1 {
-		return ""
+	if newest := versions.Newest(); newest != nil {
+		return newest.Version().String()
 	}
-	temp := versions
-	sort.Slice(temp, func(i, j int) bool {
-		return temp[i].Version().LessThan(temp[j].Version())
+	return ""
+}
+
+// Sorted will return a copy of the versions sorted from oldest to newest
+func (versions VersionFetcherArray) Sorted() VersionFetcherArray {
+	sorted := make(VersionFetcherArray, len(versions))
+	copy(sorted, versions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Version().LessThan(sorted[j].Version())
+	})
+	return sorted
+}
+
+// Dedup will return a copy of the versions with only the first occurrence of each version.
+// Versions that differ only by build metadata are considered equal, as with semver.Version.Equal.
+func (versions VersionFetcherArray) Dedup() VersionFetcherArray {
+	seen := make(map[string]bool)
+	return collections.FilterFunc(versions, func(version VersionFetcher) bool {
+		key := versionKey(version)
+		if seen[key] {
+			return false
+		}
+		seen[key] = true
+		return true
+	})
+}
+
+// Newest will return the newest version, or nil if there are no versions
+func (versions VersionFetcherArray) Newest() VersionFetcher {
+	var newest VersionFetcher
+	for _, version := range versions {
+		if newest == nil || version.Version().GreaterThan(newest.Version()) {
+			newest = version
+		}
+	}
+	return newest
+}
+
+// NewestPerMajor will return the newest version of each major line, sorted from oldest to newest
+func (versions VersionFetcherArray) NewestPerMajor() VersionFetcherArray {
+	return versions.newestPer(func(version VersionFetcher) string {
+		return fmt.Sprintf("%d", version.Version().Major())
+	})
+}
+
+// NewestPerMinor will return the newest version of each major.minor line, sorted from oldest to newest
+func (versions VersionFetcherArray) NewestPerMinor() VersionFetcherArray {
+	return versions.newestPer(minorLine)
+}
+
+// FilterByConstraint will return those versions that satisfy the constraint, in their original order
+func (versions VersionFetcherArray) FilterByConstraint(constraint *semver.Constraints) VersionFetcherArray {
+	return collections.FilterFunc(versions, func(version VersionFetcher) bool {
+		return constraint.Check(version.Version())
+	})
+}
+
+// Between will return those versions that are at least lower and at most upper, in their original order
+func (versions VersionFetcherArray) Between(lower, upper *semver.Version) VersionFetcherArray {
+	return collections.FilterFunc(versions, func(version VersionFetcher) bool {
+		return !version.Version().LessThan(lower) && !version.Version().GreaterThan(upper)
+	})
+}
+
+// Contains reports whether any of the versions is equal to the given version
+func (versions VersionFetcherArray) Contains(version *semver.Version) bool {
+	for _, v := range versions {
+		if v.Version().Equal(version) {
+			return true
+		}
+	}
+	return false
+}
+
+func (versions VersionFetcherArray) newestPer(line func(VersionFetcher) string) VersionFetcherArray {
+	newest := make(map[string]VersionFetcher)
+	for _, version := range versions {
+		key := line(version)
+		if current, ok := newest[key]; !ok || version.Version().GreaterThan(current.Version()) {
+			newest[key] = version
+		}
+	}
+
+	result := NewVersionFetcherArray()
+	for _, version := range newest {
+		result = append(result, version)
+	}
+	return result.Sorted()
+}
+
+// SortedNewestFirst will return a VersionFetcherArray of the versions sorted from newest to oldest.
+// Equal versions keep their original order, and the input is not modified.
+func SortedNewestFirst[T VersionFetcher](versions []T) VersionFetcherArray {
+	sorted := VersionFetcherArray(collections.TransformFunc(versions, func(version T) VersionFetcher {
+		return version
+	}))
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Version().GreaterThan(sorted[j].Version())
 	})
-	return temp[len(temp)-1].Version().String()
+	return sorted
 }
 
 func NewSimpleVersionFetcher(version *semver.Version) SimpleVersionFetcher {
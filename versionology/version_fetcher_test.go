@@ -3,6 +3,7 @@ package versionology_test
 import (
 	"testing"
 
+	"github.com/Masterminds/semver/v3"
 	. "github.com/onsi/gomega"
 	"github.com/paketo-buildpacks/libdependency/versionology"
 	"github.com/sclevine/spec"
@@ -46,6 +47,14 @@ func testVersionFetcher(t *testing.T, context spec.G, it spec.S) {
 			Expect(versions.GetNewestVersion()).To(Equal("7.8.9"))
 		})
 
+		it("will not modify the receiver", func() {
+			versions, err = versionology.NewSimpleVersionFetcherArray("7.8.9", "1.2.3", "4.5.6")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(versions.GetNewestVersion()).To(Equal("7.8.9"))
+			Expect(versions.GetVersionStrings()).To(Equal([]string{"7.8.9", "1.2.3", "4.5.6"}))
+		})
+
 		context("failure cases", func() {
 			context("with no versions", func() {
 				it.Before(func() {
@@ -58,4 +67,100 @@ func testVersionFetcher(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 	})
+
+	context("collection methods", func() {
+		var versions versionology.VersionFetcherArray
+
+		it.Before(func() {
+			var err error
+			versions, err = versionology.NewSimpleVersionFetcherArray(
+				"2.1.0", "1.0.0", "2.0.1", "1.1.2", "2.1.0+build", "1.1.1", "3.0.0")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it.After(func() {
+			Expect(versions.GetVersionStrings()).To(Equal([]string{
+				"2.1.0", "1.0.0", "2.0.1", "1.1.2", "2.1.0+build", "1.1.1", "3.0.0",
+			}), "the receiver must not be modified")
+		})
+
+		context("Sorted", func() {
+			it("will return the versions from oldest to newest", func() {
+				Expect(versions.Sorted().GetVersionStrings()).To(Equal([]string{
+					"1.0.0", "1.1.1", "1.1.2", "2.0.1", "2.1.0", "2.1.0+build", "3.0.0",
+				}))
+			})
+		})
+
+		context("Dedup", func() {
+			it("will keep the first occurrence of each version", func() {
+				Expect(versions.Dedup().GetVersionStrings()).To(Equal([]string{
+					"2.1.0", "1.0.0", "2.0.1", "1.1.2", "1.1.1", "3.0.0",
+				}))
+			})
+		})
+
+		context("Newest", func() {
+			it("will return the newest version", func() {
+				Expect(versions.Newest().Version().String()).To(Equal("3.0.0"))
+			})
+
+			it("will return nil with no versions", func() {
+				Expect(versionology.NewVersionFetcherArray().Newest()).To(BeNil())
+			})
+		})
+
+		context("NewestPerMajor", func() {
+			it("will return the newest version of each major", func() {
+				Expect(versions.NewestPerMajor().GetVersionStrings()).To(Equal([]string{"1.1.2", "2.1.0", "3.0.0"}))
+			})
+		})
+
+		context("NewestPerMinor", func() {
+			it("will return the newest version of each minor", func() {
+				Expect(versions.NewestPerMinor().GetVersionStrings()).To(Equal([]string{"1.0.0", "1.1.2", "2.0.1", "2.1.0", "3.0.0"}))
+			})
+		})
+
+		context("FilterByConstraint", func() {
+			it("will return the versions that satisfy the constraint", func() {
+				constraint, err := semver.NewConstraint("1.1.*")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versions.FilterByConstraint(constraint).GetVersionStrings()).To(Equal([]string{"1.1.2", "1.1.1"}))
+			})
+		})
+
+		context("Between", func() {
+			it("will return the versions within the inclusive range", func() {
+				Expect(versions.Between(semver.MustParse("1.1.1"), semver.MustParse("2.0.1")).GetVersionStrings()).To(Equal([]string{
+					"2.0.1", "1.1.2", "1.1.1",
+				}))
+			})
+		})
+
+		context("Contains", func() {
+			it("will report whether the version is present", func() {
+				Expect(versions.Contains(semver.MustParse("1.1.2"))).To(BeTrue())
+				Expect(versions.Contains(semver.MustParse("1.1.3"))).To(BeFalse())
+			})
+		})
+	})
+
+	context("SortedNewestFirst", func() {
+		it("will sort the versions from newest to oldest", func() {
+			fetchers := []versionology.SimpleVersionFetcher{
+				versionology.NewSimpleVersionFetcher(semver.MustParse("1.2.3")),
+				versionology.NewSimpleVersionFetcher(semver.MustParse("2.0.0")),
+				versionology.NewSimpleVersionFetcher(semver.MustParse("1.10.0")),
+			}
+
+			sorted := versionology.SortedNewestFirst(fetchers)
+			Expect(sorted.GetVersionStrings()).To(Equal([]string{"2.0.0", "1.10.0", "1.2.3"}))
+
+			Expect(fetchers[0].Version().String()).To(Equal("1.2.3"))
+			Expect(fetchers[1].Version().String()).To(Equal("2.0.0"))
+			Expect(fetchers[2].Version().String()).To(Equal("1.10.0"))
+		})
+	})
 }
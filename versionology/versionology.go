@@ -2,8 +2,10 @@ package versionology
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/paketo-buildpacks/libdependency/collections"
 )
 
@@ -23,19 +25,21 @@ func ConstraintsToString(semverVersions []Constraint) []string {
 	})
 }
 
-// LogAllVersions will print out a JSON array of the versions arranged as a block table.
+// LogAllVersions will print out a JSON array of the versions arranged as a block table, from newest to oldest.
+// The input is not modified.
 // See Example tests for demonstration.
 func LogAllVersions(id, description string, versions []VersionFetcher) {
+	logVersions(id, description, reversed(sortedAscending(versions)))
+}
+
+// logVersions will print the versions in the order given, see LogAllVersions
+func logVersions(id, description string, versions []VersionFetcher) {
 	fmtString := "Found %d versions of %s %s\n"
 	if len(versions) == 1 {
 		fmtString = "Found %d version of %s %s\n"
 	}
 	fmt.Printf(fmtString, len(versions), id, description)
 
-	sort.Slice(versions, func(i, j int) bool {
-		return versions[i].Version().GreaterThan(versions[j].Version())
-	})
-
 	fmt.Printf("[\n  ")
 	strings := VersionFetcherToString(versions)
 
@@ -68,86 +72,131 @@ func LogAllVersions(id, description string, versions []VersionFetcher) {
 // FilterUpstreamVersionsByConstraints will return only those versions with the following properties:
 // - contained in upstreamVersions
 // - satisfy at least one constraint
-// - newer than all existing dependencies
+// - not excluded by that constraint
+// - newer than all existing dependencies (within the same major.minor line when using PatchWindowMinor)
+//
+// When a constraint has Backfill set, the last property is replaced by:
+// - within the newest Patches versions of the constraint, but not found in the existing dependencies
+//
+// The upstream and existing versions are each sorted only once, and the upstream versions for each constraint
+// are compared to the newest existing dependency of that constraint via binary search,
+// so that this scales to upstreams with many thousands of versions.
+// The result is sorted from newest to oldest.
 func FilterUpstreamVersionsByConstraints(
 	id string,
 	upstreamVersions VersionFetcherArray,
 	constraints []Constraint,
 	existingVersion VersionFetcherArray) VersionFetcherArray {
 
-	constraintsToDependencies := make(map[Constraint]VersionFetcherArray)
+	sortedUpstreamVersions := sortedAscending(upstreamVersions)
+	sortedExistingVersions := reversed(sortedAscending(existingVersion))
 
-	for _, dependency := range existingVersion {
-		for _, constraint := range constraints {
-			if constraint.Check(dependency) {
-				constraintsToDependencies[constraint] = append(constraintsToDependencies[constraint], dependency)
-			}
-		}
-	}
+	var outputVersions []VersionFetcher
 
-	constraintsToInputVersion := make(map[Constraint][]VersionFetcher)
+	for _, constraint := range constraints {
+		inputVersions := constraint.matching(id, sortedUpstreamVersions)
+		if len(inputVersions) < 1 {
+			continue
+		}
 
-	for _, version := range upstreamVersions {
-		for _, constraint := range constraints {
-			if constraint.Check(version) {
-				constraintsToInputVersion[constraint] = append(constraintsToInputVersion[constraint], version)
+		constraintDescription := fmt.Sprintf("for constraint %s", constraint.Constraint.String())
+		logVersions(id, constraintDescription, reversed(inputVersions))
+
+		var constraintOutputVersions []VersionFetcher
+
+		switch {
+		case constraint.Backfill:
+			existingDependencies := collections.FilterFunc(sortedExistingVersions, constraint.Check)
+			constraintOutputVersions = constraint.MissingFromWindow(inputVersions, existingDependencies)
+			constraintDescription = fmt.Sprintf("missing from the newest %s for constraint %s",
+				constraint.PatchesDescription(),
+				constraint.Constraint.String())
+
+		case constraint.Window == PatchWindowMinor:
+			existingDependencies := collections.FilterFunc(sortedExistingVersions, constraint.Check)
+			constraintOutputVersions = constraint.LimitPatches(constraint.newerThan(inputVersions, existingDependencies))
+			constraintDescription = fmt.Sprintf("newer than '%s' for constraint %s, after limiting for %s",
+				newestVersionString(existingDependencies),
+				constraint.Constraint.String(),
+				constraint.PatchesDescription())
+
+		default:
+			// sortedExistingVersions is newest first, so the first match is the newest existing dependency
+			var newest *semver.Version
+			if index := slices.IndexFunc(sortedExistingVersions, constraint.Check); index >= 0 {
+				newest = sortedExistingVersions[index].Version()
 			}
+
+			constraintOutputVersions = constraint.LimitPatches(newerThan(inputVersions, newest))
+			constraintDescription = fmt.Sprintf("newer than '%s' for constraint %s, after limiting for %s",
+				versionString(newest),
+				constraint.Constraint.String(),
+				constraint.PatchesDescription())
 		}
-	}
 
-	for constraint, versions := range constraintsToInputVersion {
-		constraintDescription := fmt.Sprintf("for constraint %s", constraint.Constraint.String())
-		LogAllVersions(id, constraintDescription, versions)
-	}
+		if len(constraintOutputVersions) < 1 {
+			continue
+		}
 
-	constraintsToOutputVersions := make(map[Constraint][]VersionFetcher)
+		logVersions(id, constraintDescription, reversed(constraintOutputVersions))
 
-	for constraint, upstreamVersionsForConstraint := range constraintsToInputVersion {
-		existingDependencies := constraintsToDependencies[constraint]
+		outputVersions = append(outputVersions, constraintOutputVersions...)
+	}
 
-	ConstraintsToInputVersionLoop:
-		for _, upstreamVersionForConstraint := range upstreamVersionsForConstraint {
-			for _, existingDependency := range existingDependencies {
-				if upstreamVersionForConstraint.Version().LessThan(existingDependency.Version()) || upstreamVersionForConstraint.Version().Equal(existingDependency.Version()) {
-					continue ConstraintsToInputVersionLoop
-				}
-			}
-			constraintsToOutputVersions[constraint] = append(constraintsToOutputVersions[constraint], upstreamVersionForConstraint)
-		}
+	if len(constraints) < 1 {
+		outputVersions = newerThan(sortedUpstreamVersions, newestVersion(existingVersion))
 	}
 
-	var outputVersions []VersionFetcher
+	outputVersions = reversed(sortedAscending(outputVersions))
+	logVersions(id, "as new versions", outputVersions)
+	return outputVersions
+}
 
-	for constraint, constraintsToOutputVersion := range constraintsToOutputVersions {
-		sort.Slice(constraintsToOutputVersion, func(i, j int) bool {
-			return constraintsToOutputVersion[i].Version().LessThan(constraintsToOutputVersion[j].Version())
-		})
+// newerThan will return the suffix of the ascending versions that is newer than newest.
+// All versions are returned when newest is nil.
+func newerThan(versions []VersionFetcher, newest *semver.Version) []VersionFetcher {
+	if newest == nil {
+		return versions
+	}
 
-		if constraint.Patches < len(constraintsToOutputVersion) {
-			constraintsToOutputVersion = constraintsToOutputVersion[len(constraintsToOutputVersion)-constraint.Patches:]
-		}
+	index := sort.Search(len(versions), func(i int) bool {
+		return versions[i].Version().GreaterThan(newest)
+	})
+
+	return versions[index:]
+}
 
-		constraintDescription := fmt.Sprintf("newer than '%s' for constraint %s, after limiting for %d patches",
-			constraintsToDependencies[constraint].GetNewestVersion(),
-			constraint.Constraint.String(),
-			constraint.Patches)
-		LogAllVersions(id, constraintDescription, constraintsToOutputVersion)
+// sortedAscending will return a copy of versions sorted from oldest to newest
+func sortedAscending(versions []VersionFetcher) []VersionFetcher {
+	return VersionFetcherArray(versions).Sorted()
+}
 
-		outputVersions = append(outputVersions, constraintsToOutputVersion...)
+// newestVersion will return the newest of the versions, or nil if there are none
+func newestVersion(versions []VersionFetcher) *semver.Version {
+	if newest := VersionFetcherArray(versions).Newest(); newest != nil {
+		return newest.Version()
 	}
+	return nil
+}
 
-	if len(constraints) < 1 {
-	ZeroConstraintsLoop:
-		for _, upstreamVersion := range upstreamVersions {
-			for _, dependency := range existingVersion {
-				if upstreamVersion.Version().LessThan(dependency.Version()) || upstreamVersion.Version().Equal(dependency.Version()) {
-					continue ZeroConstraintsLoop
-				}
-			}
-			outputVersions = append(outputVersions, upstreamVersion)
-		}
+// newestVersionString will return the newest of the versions as a string, or "" if there are none
+func newestVersionString(versions []VersionFetcher) string {
+	return versionString(newestVersion(versions))
+}
+
+// versionString will return the version as a string, or "" if it is nil
+func versionString(version *semver.Version) string {
+	if version == nil {
+		return ""
 	}
+	return version.String()
+}
 
-	LogAllVersions(id, "as new versions", outputVersions)
-	return outputVersions
+// reversed will return a copy of versions in the reverse order
+func reversed(versions []VersionFetcher) []VersionFetcher {
+	result := make([]VersionFetcher, len(versions))
+	for i, version := range versions {
+		result[len(versions)-1-i] = version
+	}
+	return result
 }
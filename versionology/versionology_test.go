@@ -1,6 +1,8 @@
 package versionology_test
 
 import (
+	"fmt"
+	"os"
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
@@ -86,5 +88,176 @@ func testVersionology(t *testing.T, context spec.G, it spec.S) {
 
 			Expect(filteredVersions.GetVersionStrings()).To(ConsistOf("6.1.3", "6.1.4", "6.1.5", "6.1.6", "7.0.5", "7.0.6"))
 		})
+
+		context("when constraints have exclusions", func() {
+			it("will not return excluded versions", func() {
+				upstreamVersions, err := versionology.NewSimpleVersionFetcherArray(
+					"2.0.0", "2.0.1", "2.0.2", "2.0.3", "2.0.4", "2.0.5", "2.0.6")
+				Expect(err).NotTo(HaveOccurred())
+
+				constraint, err := versionology.NewConstraintWithExclusions(cargo.ConfigMetadataDependencyConstraint{
+					Constraint: "2.*.*",
+					Patches:    3,
+				}, "2.0.6", ">=2.0.2-0 <2.0.4")
+				Expect(err).NotTo(HaveOccurred())
+
+				dependencies, err := versionology.NewSimpleVersionFetcherArray("2.0.0")
+				Expect(err).NotTo(HaveOccurred())
+
+				filteredVersions := versionology.FilterUpstreamVersionsByConstraints("dep", upstreamVersions, []versionology.Constraint{constraint}, dependencies)
+
+				Expect(filteredVersions.GetVersionStrings()).To(ConsistOf("2.0.1", "2.0.4", "2.0.5"))
+			})
+
+			it("will return an error naming an invalid exclusion", func() {
+				_, err := versionology.NewConstraintWithExclusions(cargo.ConfigMetadataDependencyConstraint{
+					Constraint: "2.*.*",
+				}, "2.0.6", "not-a-version")
+				Expect(err).To(MatchError(ContainSubstring("invalid exclusion 'not-a-version'")))
+			})
+
+			it("will keep constraints comparable", func() {
+				constraint, err := versionology.NewConstraintWithExclusions(cargo.ConfigMetadataDependencyConstraint{
+					Constraint: "2.*.*",
+				}, "2.0.6")
+				Expect(err).NotTo(HaveOccurred())
+
+				seen := map[versionology.Constraint]bool{constraint: true}
+				Expect(seen[constraint]).To(BeTrue())
+				copied := constraint
+				Expect(copied == constraint).To(BeTrue())
+			})
+		})
+
+		context("when a constraint uses a per-minor patch window", func() {
+			it("will keep the newest patches for each minor line", func() {
+				upstreamVersions, err := versionology.NewSimpleVersionFetcherArray(
+					"3.10.0", "3.10.1", "3.10.2", "3.10.3",
+					"3.11.0", "3.11.1", "3.11.2",
+					"3.12.0")
+				Expect(err).NotTo(HaveOccurred())
+
+				c3, err := semver.NewConstraint("3.*")
+				Expect(err).NotTo(HaveOccurred())
+				constraints := []versionology.Constraint{
+					{
+						Constraint: c3,
+						Patches:    2,
+						Window:     versionology.PatchWindowMinor,
+					},
+				}
+
+				dependencies, err := versionology.NewSimpleVersionFetcherArray("3.10.2", "3.11.0")
+				Expect(err).NotTo(HaveOccurred())
+
+				filteredVersions := versionology.FilterUpstreamVersionsByConstraints("dep", upstreamVersions, constraints, dependencies)
+
+				Expect(filteredVersions.GetVersionStrings()).To(ConsistOf("3.10.3", "3.11.1", "3.11.2", "3.12.0"))
+			})
+
+			it("will leave out minor lines older than those of existing dependencies", func() {
+				upstreamVersions, err := versionology.NewSimpleVersionFetcherArray(
+					"3.0.0", "3.0.1",
+					"3.10.0", "3.10.1",
+					"3.11.0", "3.11.1",
+					"3.12.0", "3.12.1")
+				Expect(err).NotTo(HaveOccurred())
+
+				c3, err := semver.NewConstraint("3.*")
+				Expect(err).NotTo(HaveOccurred())
+
+				dependencies, err := versionology.NewSimpleVersionFetcherArray("3.11.0", "3.12.0")
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, backfill := range []bool{false, true} {
+					constraints := []versionology.Constraint{
+						{
+							Constraint: c3,
+							Patches:    2,
+							Window:     versionology.PatchWindowMinor,
+							Backfill:   backfill,
+						},
+					}
+
+					filteredVersions := versionology.FilterUpstreamVersionsByConstraints("dep", upstreamVersions, constraints, dependencies)
+
+					Expect(filteredVersions.GetVersionStrings()).To(Equal([]string{"3.12.1", "3.11.1"}), "backfill %t", backfill)
+				}
+			})
+		})
+
+		context("when a constraint has backfill enabled", func() {
+			it("will return versions missing from the patch window", func() {
+				upstreamVersions, err := versionology.NewSimpleVersionFetcherArray(
+					"1.2.2", "1.2.3", "1.2.4", "1.2.5", "1.2.6", "1.2.7")
+				Expect(err).NotTo(HaveOccurred())
+
+				c12, err := semver.NewConstraint("1.2.*")
+				Expect(err).NotTo(HaveOccurred())
+				constraints := []versionology.Constraint{
+					{
+						Constraint: c12,
+						Patches:    4,
+						Backfill:   true,
+					},
+				}
+
+				dependencies, err := versionology.NewSimpleVersionFetcherArray("1.2.2", "1.2.4", "1.2.6")
+				Expect(err).NotTo(HaveOccurred())
+
+				filteredVersions := versionology.FilterUpstreamVersionsByConstraints("dep", upstreamVersions, constraints, dependencies)
+
+				Expect(filteredVersions.GetVersionStrings()).To(ConsistOf("1.2.5", "1.2.7"))
+			})
+		})
 	})
 }
+
+func BenchmarkFilterUpstreamVersionsByConstraints(b *testing.B) {
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	os.Stdout = devNull
+	b.Cleanup(func() {
+		os.Stdout = stdout
+		devNull.Close()
+	})
+
+	for _, size := range []struct {
+		majors, minors, patches, existingPatches uint64
+	}{
+		{majors: 2, minors: 10, patches: 50, existingPatches: 5},
+		{majors: 5, minors: 40, patches: 50, existingPatches: 40},
+		{majors: 20, minors: 50, patches: 50, existingPatches: 40},
+	} {
+		var upstreamVersions, existingVersions versionology.VersionFetcherArray
+		for major := uint64(0); major < size.majors; major++ {
+			for minor := uint64(0); minor < size.minors; minor++ {
+				for patch := uint64(0); patch < size.patches; patch++ {
+					version := versionology.NewSimpleVersionFetcher(semver.New(major, minor, patch, "", ""))
+					upstreamVersions = append(upstreamVersions, version)
+					if patch < size.existingPatches {
+						existingVersions = append(existingVersions, version)
+					}
+				}
+			}
+		}
+
+		var constraints []versionology.Constraint
+		for major := uint64(0); major < size.majors; major++ {
+			c, err := semver.NewConstraint(fmt.Sprintf("%d.*", major))
+			if err != nil {
+				b.Fatal(err)
+			}
+			constraints = append(constraints, versionology.Constraint{Constraint: c, Patches: 10})
+		}
+
+		b.Run(fmt.Sprintf("upstream=%d/existing=%d/constraints=%d", len(upstreamVersions), len(existingVersions), len(constraints)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				versionology.FilterUpstreamVersionsByConstraints("dep", upstreamVersions, constraints, existingVersions)
+			}
+		})
+	}
+}